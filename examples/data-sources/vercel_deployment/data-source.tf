@@ -0,0 +1,11 @@
+// The latest production deployment of a project
+data "vercel_deployment" "production" {
+  project_id = vercel_project.my_project.id
+  target     = "production"
+  state      = "READY"
+}
+
+// A deployment by its id or url
+data "vercel_deployment" "by_url" {
+  id = "mercury-xxxxxxxxx.vercel.app"
+}
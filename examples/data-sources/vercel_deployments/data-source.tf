@@ -0,0 +1,11 @@
+// The preview deployments of a branch, e.g. to post their urls to a pull request
+data "vercel_deployments" "previews" {
+  project_id = vercel_project.my_project.id
+  branch     = "feature/login"
+  state      = "READY"
+  limit      = 5
+}
+
+output "preview_urls" {
+  value = [for d in data.vercel_deployments.previews.deployments : "https://${d.url}"]
+}
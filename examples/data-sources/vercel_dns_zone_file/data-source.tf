@@ -0,0 +1,10 @@
+// Export the records of a domain before moving it to another dns provider
+data "vercel_dns_zone_file" "chronark_com" {
+  domain                 = "chronark.com"
+  include_system_records = false
+}
+
+resource "local_file" "zone" {
+  filename = "${path.module}/chronark.com.zone"
+  content  = data.vercel_dns_zone_file.chronark_com.zone_file
+}
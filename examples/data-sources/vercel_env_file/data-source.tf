@@ -0,0 +1,19 @@
+data "vercel_env_file" "production" {
+  content = file("${path.module}/.env.production")
+  target  = ["production"]
+  type    = "encrypted"
+}
+
+resource "vercel_project_env" "my_project" {
+  project_id = vercel_project.my_project.id
+
+  dynamic "variable" {
+    for_each = data.vercel_env_file.production.variable
+    content {
+      key    = variable.value.key
+      value  = variable.value.value
+      type   = variable.value.type
+      target = variable.value.target
+    }
+  }
+}
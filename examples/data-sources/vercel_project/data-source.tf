@@ -0,0 +1,9 @@
+// Reference a project of another team by its name
+data "vercel_project" "api" {
+  name    = "api"
+  team_id = "team_xxxxxxxxxxxxxxxxxxxxxxxx"
+}
+
+output "api_production_branch" {
+  value = data.vercel_project.api.link[0].production_branch
+}
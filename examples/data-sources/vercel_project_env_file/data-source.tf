@@ -0,0 +1,11 @@
+// Write the preview variables of a branch to a local dotenv file
+data "vercel_project_env_file" "preview" {
+  project_id = vercel_project.my_project.id
+  target     = "preview"
+  git_branch = "staging"
+}
+
+resource "local_file" "env" {
+  filename          = "${path.module}/.env.preview"
+  sensitive_content = data.vercel_project_env_file.preview.content
+}
@@ -0,0 +1,9 @@
+// All next.js projects whose name starts with `shop-`
+data "vercel_projects" "shops" {
+  name_prefix = "shop-"
+  framework   = "nextjs"
+}
+
+output "shop_ids" {
+  value = [for p in data.vercel_projects.shops.projects : p.id]
+}
@@ -10,3 +10,20 @@ terraform {
 provider "vercel" {
   token = "<YOUR_TOKEN>"
 }
+
+// Resources of this provider are created in the `my-team` team unless they set `team_id` themselves
+provider "vercel" {
+  alias     = "my_team"
+  token     = "<YOUR_TOKEN>"
+  team_slug = "my-team"
+}
+
+resource "vercel_project" "team_project" {
+  provider = vercel.my_team
+
+  name = "mercury"
+  git_repository {
+    type = "github"
+    repo = "chronark/mercury"
+  }
+}
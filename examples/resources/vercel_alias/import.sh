@@ -0,0 +1,3 @@
+# Aliases can be imported using `[team_id/]project_id/domain`
+terraform import vercel_alias.flare prj_xxxxxxxxxxxxxxxxxxxxxxxxxxxx/www.chronark.com
+terraform import vercel_alias.flare team_xxxxxxxxxxxxxxxxxxxxxxxx/prj_xxxxxxxxxxxxxxxxxxxxxxxxxxxx/www.chronark.com
@@ -0,0 +1,19 @@
+resource "vercel_project" "my_project" {
+  name = "mercury"
+  git_repository {
+    type = "github"
+    repo = "chronark/mercury"
+  }
+}
+
+// Deploy the build output, a new deployment is created whenever a file in the directory changes
+resource "vercel_deployment" "my_deployment" {
+  name       = "mercury"
+  project_id = vercel_project.my_project.id
+  directory  = "${path.module}/dist"
+  production = true
+}
+
+output "url" {
+  value = "https://${vercel_deployment.my_deployment.url}"
+}
@@ -0,0 +1,3 @@
+# DNS records can be imported using `[team_id/]domain/record_id`
+terraform import vercel_dns.www chronark.com/rec_xxxxxxxxxxxxxxxxxxxxxxxx
+terraform import vercel_dns.www team_xxxxxxxxxxxxxxxxxxxxxxxx/chronark.com/rec_xxxxxxxxxxxxxxxxxxxxxxxx
@@ -10,4 +10,32 @@ resource "vercel_dns" "www" {
   type   = "CNAME"
   value  = "www.${vercel_domain.chronark_com.name}"
   name   = "www"
-}
\ No newline at end of file
+}
+
+resource "vercel_dns" "mx" {
+  domain      = vercel_domain.chronark_com.name
+  type        = "MX"
+  name        = ""
+  value       = "mx1.improvmx.com"
+  mx_priority = 10
+  comment     = "Mail forwarding"
+}
+
+resource "vercel_dns" "sip" {
+  domain = vercel_domain.chronark_com.name
+  type   = "SRV"
+  name   = "_sip._tcp"
+  srv {
+    priority = 10
+    weight   = 5
+    port     = 5060
+    target   = "sip.chronark.com"
+  }
+}
+
+resource "vercel_dns" "caa" {
+  domain = vercel_domain.chronark_com.name
+  type   = "CAA"
+  name   = ""
+  value  = "0 issue \"letsencrypt.org\""
+}
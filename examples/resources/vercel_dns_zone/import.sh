@@ -0,0 +1,3 @@
+# DNS zones can be imported using `[team_id/]domain`
+terraform import vercel_dns_zone.chronark_com chronark.com
+terraform import vercel_dns_zone.chronark_com team_xxxxxxxxxxxxxxxxxxxxxxxx/chronark.com
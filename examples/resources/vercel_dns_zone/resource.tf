@@ -0,0 +1,30 @@
+resource "vercel_domain" "chronark_com" {
+  name = "chronark.com"
+}
+
+resource "vercel_dns_zone" "chronark_com" {
+  domain = vercel_domain.chronark_com.name
+
+  record {
+    type  = "CNAME"
+    name  = "www"
+    value = "cname.vercel-dns.com"
+  }
+
+  record {
+    type        = "MX"
+    name        = ""
+    value       = "mx1.improvmx.com"
+    mx_priority = 10
+  }
+}
+
+# Alternatively the records can be read from a BIND zone file
+resource "vercel_domain" "chronark_dev" {
+  name = "chronark.dev"
+}
+
+resource "vercel_dns_zone" "chronark_dev" {
+  domain    = vercel_domain.chronark_dev.name
+  zone_file = file("${path.module}/chronark.dev.zone")
+}
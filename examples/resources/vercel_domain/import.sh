@@ -0,0 +1,3 @@
+# Domains can be imported using `[team_id/]name`
+terraform import vercel_domain.google-com google.com
+terraform import vercel_domain.google-com team_xxxxxxxxxxxxxxxxxxxxxxxx/google.com
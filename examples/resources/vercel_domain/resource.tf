@@ -1,4 +1,26 @@
-
 resource "vercel_domain" "google-com" {
   name = "google.com"
-}
\ No newline at end of file
+}
+
+resource "vercel_domain" "chronark-com" {
+  name                  = "chronark.com"
+  wait_for_verification = true
+
+  timeouts {
+    create = "1h"
+  }
+}
+
+resource "vercel_domain" "chronark-dev" {
+  name           = "chronark.dev"
+  method         = "buy"
+  expected_price = 20
+  auto_renew     = true
+}
+
+resource "vercel_domain" "chronark-io" {
+  name           = "chronark.io"
+  method         = "transfer-in"
+  auth_code      = var.chronark_io_auth_code
+  expected_price = 50
+}
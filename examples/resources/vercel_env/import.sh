@@ -0,0 +1,3 @@
+# Environment variables can be imported using `[team_id/]project_id/env_id`
+terraform import vercel_env.my_env prj_xxxxxxxxxxxxxxxxxxxxxxxxxxxx/xxxxxxxxxxxxxxxx
+terraform import vercel_env.my_env team_xxxxxxxxxxxxxxxxxxxxxxxx/prj_xxxxxxxxxxxxxxxxxxxxxxxxxxxx/xxxxxxxxxxxxxxxx
@@ -0,0 +1,3 @@
+# Projects can be imported using the project ID, optionally prefixed with the team ID
+terraform import vercel_project.my_project prj_xxxxxxxxxxxxxxxxxxxxxxxxxxxx
+terraform import vercel_project.my_project team_xxxxxxxxxxxxxxxxxxxxxxxx/prj_xxxxxxxxxxxxxxxxxxxxxxxxxxxx
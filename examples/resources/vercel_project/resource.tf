@@ -1,8 +1,10 @@
-
 resource "vercel_project" "my_project" {
   name = "mercury"
   git_repository {
     type = "github"
     repo = "chronark/mercury"
   }
-}
\ No newline at end of file
+  production_branch               = "main"
+  command_for_ignoring_build_step = "git diff --quiet HEAD^ HEAD ./"
+  git_fork_protection             = true
+}
@@ -0,0 +1,3 @@
+# Project domains can be imported using `[team_id/]project_id/name`
+terraform import vercel_project_domain.my_domain prj_xxxxxxxxxxxxxxxxxxxxxxxxxxxx/www.chronark.com
+terraform import vercel_project_domain.my_domain team_xxxxxxxxxxxxxxxxxxxxxxxx/prj_xxxxxxxxxxxxxxxxxxxxxxxxxxxx/www.chronark.com
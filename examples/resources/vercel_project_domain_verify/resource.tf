@@ -0,0 +1,19 @@
+resource "vercel_project_domain" "my_domain" {
+  project_id = vercel_project.my_project.id
+  name       = "www.chronark.com"
+}
+
+// The challenge is only known while the domain is unverified, so the record is usually created outside of vercel
+resource "vercel_dns" "challenge" {
+  domain = "chronark.com"
+  type   = "TXT"
+  name   = "_vercel"
+  value  = "vc-domain-verify=www.chronark.com,xxxxxxxxxxxxxxxxxxxx"
+  ttl    = 60
+}
+
+resource "vercel_project_domain_verify" "my_domain" {
+  project_id = vercel_project_domain.my_domain.project_id
+  name       = vercel_project_domain.my_domain.name
+  depends_on = [vercel_dns.challenge]
+}
@@ -0,0 +1,3 @@
+# All variables of a project can be imported using `[team_id/]project_id`
+terraform import vercel_project_env.my_project prj_xxxxxxxxxxxxxxxxxxxxxxxx
+terraform import vercel_project_env.my_project team_xxxxxxxxxxxxxxxxxxxxxxxx/prj_xxxxxxxxxxxxxxxxxxxxxxxx
@@ -0,0 +1,28 @@
+resource "vercel_project" "my_project" {
+  name = "mercury"
+  git_repository {
+    type = "github"
+    repo = "chronark/mercury"
+  }
+}
+
+resource "vercel_project_env" "my_project" {
+  project_id = vercel_project.my_project.id
+
+  // Delete variables that were created in the dashboard
+  remove_unmanaged = true
+
+  variable {
+    key    = "DATABASE_URL"
+    value  = var.database_url
+    type   = "sensitive"
+    target = ["production"]
+  }
+
+  variable {
+    key    = "NEXT_PUBLIC_URL"
+    value  = "https://chronark.com"
+    type   = "plain"
+    target = ["production", "preview"]
+  }
+}
@@ -0,0 +1,4 @@
+# Secrets can be imported using `[team_id/]secret_id`.
+# The value of a secret can not be read from vercel and has to be set again in the configuration.
+terraform import vercel_secret.my_secret sec_xxxxxxxxxxxxxxxxxxxxxxxx
+terraform import vercel_secret.my_secret team_xxxxxxxxxxxxxxxxxxxxxxxx/sec_xxxxxxxxxxxxxxxxxxxxxxxx
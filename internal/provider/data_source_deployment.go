@@ -0,0 +1,174 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/chronark/terraform-provider-vercel/pkg/vercel"
+	"github.com/chronark/terraform-provider-vercel/pkg/vercel/deployment"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// deploymentAttributes are the attributes shared by the `vercel_deployment` and `vercel_deployments` data sources.
+func deploymentAttributes() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"id": {
+			Description: "The unique identifier of the deployment.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"name": {
+			Description: "The name of the deployment.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"url": {
+			Description: "The url of the deployment, without protocol.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"alias": {
+			Description: "The domains pointing to this deployment. Only known for single deployments, the list of deployments returned by vercel does not contain them.",
+			Type:        schema.TypeList,
+			Computed:    true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		"project_id": {
+			Description: "The ID of the project the deployment belongs to.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"target": {
+			Description: "`production` for production deployments, empty for previews.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"branch": {
+			Description: "The git branch the deployment was built from.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"ready_state": {
+			Description: "The state of the build, one of `QUEUED`, `INITIALIZING`, `BUILDING`, `READY`, `ERROR` or `CANCELED`.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"meta": {
+			Description: "Metadata attached to the deployment, e.g. the commit it was built from.",
+			Type:        schema.TypeMap,
+			Computed:    true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		"creator_id": {
+			Description: "The unique identifier of the user who created the deployment.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"creator_username": {
+			Description: "The username of the user who created the deployment.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"created_at": {
+			Description: "A number containing the date when the deployment was created in milliseconds.",
+			Type:        schema.TypeInt,
+			Computed:    true,
+		},
+	}
+}
+
+func flattenDeployment(d deployment.Deployment) map[string]interface{} {
+	return map[string]interface{}{
+		"id":               d.ID,
+		"name":             d.Name,
+		"url":              d.URL,
+		"alias":            d.Alias,
+		"project_id":       d.ProjectID,
+		"target":           d.Target,
+		"branch":           d.Branch(),
+		"ready_state":      d.ReadyState,
+		"meta":             d.Meta,
+		"creator_id":       d.Creator.UID,
+		"creator_username": d.Creator.Username,
+		"created_at":       d.CreatedAt,
+	}
+}
+
+func dataSourceDeployment() *schema.Resource {
+	s := deploymentAttributes()
+
+	// The filters double as attributes of the deployment that was found
+	s["id"].Description = "The id or url of the deployment. When omitted, the most recent deployment matching the other arguments is used."
+	s["id"].Optional = true
+	s["id"].ConflictsWith = []string{"project_id", "target", "state", "branch"}
+	s["project_id"].Optional = true
+	s["target"].Optional = true
+	s["branch"].Optional = true
+	s["state"] = &schema.Schema{
+		Description: "Only consider deployments in this state, e.g. `READY`.",
+		Type:        schema.TypeString,
+		Optional:    true,
+	}
+	s["team_id"] = &schema.Schema{
+		Description: "By default, you can access resources contained within your own user account. To access resources owned by a team, you can pass in the team ID. Defaults to the team configured in the provider.",
+		Type:        schema.TypeString,
+		Optional:    true,
+		Computed:    true,
+	}
+
+	return &schema.Resource{
+		Description: "Retrieves a single deployment, either by its id or the latest one matching the filters. https://vercel.com/docs/api#endpoints/deployments",
+		ReadContext: dataSourceDeploymentRead,
+		Schema:      s,
+	}
+}
+
+func dataSourceDeploymentRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*vercel.Client)
+
+	teamId := inheritTeamID(d.Get("team_id").(string), meta)
+	err := d.Set("team_id", teamId)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	id := d.Get("id").(string)
+	if id == "" {
+		filter := deployment.ListDeployments{
+			ProjectID: d.Get("project_id").(string),
+			Target:    d.Get("target").(string),
+			State:     d.Get("state").(string),
+			Branch:    d.Get("branch").(string),
+			Limit:     1,
+		}
+		deployments, err := client.Deployment.List(ctx, filter, teamId)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if len(deployments) == 0 {
+			return diag.FromErr(fmt.Errorf("no deployment matches the given arguments"))
+		}
+		id = deployments[0].ID
+	}
+
+	// The list does not contain all details, so the deployment is always fetched on its own
+	found, err := client.Deployment.Read(ctx, id, teamId)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	for key, value := range flattenDeployment(found) {
+		err = d.Set(key, value)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	d.SetId(found.ID)
+
+	return diag.Diagnostics{}
+}
@@ -0,0 +1,97 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/chronark/terraform-provider-vercel/pkg/vercel"
+	"github.com/chronark/terraform-provider-vercel/pkg/vercel/deployment"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceDeployments() *schema.Resource {
+	return &schema.Resource{
+		Description: "Retrieves the most recent deployments matching the filters. https://vercel.com/docs/api#endpoints/deployments/list-deployments",
+		ReadContext: dataSourceDeploymentsRead,
+		Schema: map[string]*schema.Schema{
+			"team_id": {
+				Description: "By default, you can access resources contained within your own user account. To access resources owned by a team, you can pass in the team ID. Defaults to the team configured in the provider.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+			},
+			"project_id": {
+				Description: "Only return deployments of this project.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"target": {
+				Description: "Only return deployments with this target, e.g. `production`.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"state": {
+				Description: "Only return deployments in this state, e.g. `READY`.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"branch": {
+				Description: "Only return deployments built from this git branch.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"limit": {
+				Description:  "The maximum number of deployments to return.",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      20,
+				ValidateFunc: validation.IntBetween(1, 100),
+			},
+			"deployments": {
+				Description: "The matching deployments, newest first.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: deploymentAttributes(),
+				},
+			},
+		},
+	}
+}
+
+func dataSourceDeploymentsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*vercel.Client)
+
+	teamId := inheritTeamID(d.Get("team_id").(string), meta)
+	err := d.Set("team_id", teamId)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	filter := deployment.ListDeployments{
+		ProjectID: d.Get("project_id").(string),
+		Target:    d.Get("target").(string),
+		State:     d.Get("state").(string),
+		Branch:    d.Get("branch").(string),
+		Limit:     d.Get("limit").(int),
+	}
+	deployments, err := client.Deployment.List(ctx, filter, teamId)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	flattened := make([]interface{}, len(deployments))
+	for i, found := range deployments {
+		flattened[i] = flattenDeployment(found)
+	}
+	err = d.Set("deployments", flattened)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s/%s/%s/%s", teamId, filter.ProjectID, filter.Target, filter.State, filter.Branch))
+
+	return diag.Diagnostics{}
+}
@@ -0,0 +1,57 @@
+package provider
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceDeployments(t *testing.T) {
+	name, _ := uuid.GenerateUUID()
+	dir, err := ioutil.TempDir("", "deployment")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	testAccWriteDeploymentFile(t, dir, "<h1>hello</h1>")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceDeploymentsConfig(name, dir),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.vercel_deployment.latest", "id", "vercel_deployment.new", "id"),
+					resource.TestCheckResourceAttrPair("data.vercel_deployment.latest", "url", "vercel_deployment.new", "url"),
+					resource.TestCheckResourceAttr("data.vercel_deployment.latest", "target", "production"),
+					resource.TestCheckResourceAttr("data.vercel_deployments.all", "deployments.#", "1"),
+					resource.TestCheckResourceAttrPair("data.vercel_deployments.all", "deployments.0.id", "vercel_deployment.new", "id"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceDeploymentsConfig(name string, dir string) string {
+	return fmt.Sprintf(`
+	resource "vercel_deployment" "new" {
+		name       = "%s"
+		directory  = "%s"
+		production = true
+	}
+
+	data "vercel_deployment" "latest" {
+		project_id = vercel_deployment.new.project_id
+		target     = "production"
+	}
+
+	data "vercel_deployments" "all" {
+		project_id = vercel_deployment.new.project_id
+	}
+	`, name, dir)
+}
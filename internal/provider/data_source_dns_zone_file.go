@@ -0,0 +1,81 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/chronark/terraform-provider-vercel/pkg/vercel"
+	"github.com/chronark/terraform-provider-vercel/pkg/vercel/dns"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceDNSZoneFile() *schema.Resource {
+	return &schema.Resource{
+		Description: "Renders the dns records of a domain as an RFC 1035 zone file, e.g. to move the domain to another dns provider.",
+		ReadContext: dataSourceDNSZoneFileRead,
+		Schema: map[string]*schema.Schema{
+			"domain": {
+				Description: "The domain whose records are rendered.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"team_id": {
+				Description: "By default, you can access resources contained within your own user account. To access resources owned by a team, you can pass in the team ID. Defaults to the team configured in the provider.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+			},
+			"include_system_records": {
+				Description: "Whether the records vercel creates automatically are part of the zone file.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+			},
+			"zone_file": {
+				Description: "The records as zone file, sorted by name, type and value.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func dataSourceDNSZoneFileRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*vercel.Client)
+
+	teamId := inheritTeamID(d.Get("team_id").(string), meta)
+	err := d.Set("team_id", teamId)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	domain := d.Get("domain").(string)
+	records, err := client.DNS.List(ctx, domain, teamId)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if !d.Get("include_system_records").(bool) {
+		filtered := []dns.Record{}
+		for _, record := range records {
+			if record.Creator != dns.CreatorSystem {
+				filtered = append(filtered, record)
+			}
+		}
+		records = filtered
+	}
+
+	zone, err := dns.RenderZone(domain, records)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	err = d.Set("zone_file", zone)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", teamId, domain))
+
+	return diag.Diagnostics{}
+}
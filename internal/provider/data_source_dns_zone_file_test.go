@@ -0,0 +1,37 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceDNSZoneFile(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				resource "vercel_domain" "new" {
+					name = "acceptance-test-dns-zone-file.com"
+				}
+
+				resource "vercel_dns" "www" {
+					domain = vercel_domain.new.name
+					type   = "CNAME"
+					name   = "www"
+					value  = "cname.vercel-dns.com"
+				}
+
+				data "vercel_dns_zone_file" "export" {
+					domain = vercel_dns.www.domain
+				}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.vercel_dns_zone_file.export", "zone_file", "$ORIGIN acceptance-test-dns-zone-file.com.\nwww\t60\tIN\tCNAME\tcname.vercel-dns.com.\n"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,138 @@
+package provider
+
+import (
+	"context"
+	"crypto/sha1"
+	"fmt"
+	"sort"
+
+	"github.com/chronark/terraform-provider-vercel/pkg/vercel/env"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceEnvFile() *schema.Resource {
+	return &schema.Resource{
+		Description: "Parses a dotenv file, e.g. `.env.production`, into environment variables that can be used in `vercel_project_env` or `vercel_env`.",
+		ReadContext: dataSourceEnvFileRead,
+		Schema: map[string]*schema.Schema{
+			"content": {
+				Description: "The content of the dotenv file, e.g. `file(\".env.production\")`.",
+				Type:        schema.TypeString,
+				Required:    true,
+				Sensitive:   true,
+			},
+			"target": {
+				Description: "The targets the variables apply to, any of `development`, `preview` or `production`.",
+				Type:        schema.TypeSet,
+				Required:    true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringInSlice(envTargets, false),
+				},
+			},
+			"type": {
+				Description:  "The type of the variables, one of `plain`, `secret`, `system`, `encrypted` or `sensitive`.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      env.TypeEncrypted,
+				ValidateFunc: validation.StringInSlice(env.Types, false),
+			},
+			"git_branch": {
+				Description: "The Git branch for the variables, only accepted when the target is exclusively preview.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"variables": {
+				Description: "The variables as a map from key to value.",
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Sensitive:   true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"variable": {
+				Description: "The variables in the order of the file, in the shape of the `variable` blocks of `vercel_project_env`.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Sensitive:   true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"key": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"value": {
+							Type:      schema.TypeString,
+							Computed:  true,
+							Sensitive: true,
+						},
+						"type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"target": {
+							Type:     schema.TypeSet,
+							Computed: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"git_branch": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceEnvFileRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	content := d.Get("content").(string)
+	entries, err := env.ParseDotenv(content)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("invalid dotenv file: %w", err))
+	}
+
+	target := []string{}
+	for _, t := range d.Get("target").(*schema.Set).List() {
+		target = append(target, t.(string))
+	}
+	sort.Strings(target)
+
+	var gitBranch *string
+	if b := d.Get("git_branch").(string); b != "" {
+		gitBranch = &b
+	}
+
+	variables := map[string]interface{}{}
+	variable := []interface{}{}
+	for _, e := range env.FromDotenv(entries, d.Get("type").(string), target, gitBranch) {
+		variables[e.Key] = e.Value
+		variable = append(variable, map[string]interface{}{
+			"key":        e.Key,
+			"value":      e.Value,
+			"type":       e.Type,
+			"target":     e.Target,
+			"git_branch": d.Get("git_branch").(string),
+		})
+	}
+
+	err = d.Set("variables", variables)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	err = d.Set("variable", variable)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// The id must not reveal the content
+	d.SetId(fmt.Sprintf("%x", sha1.Sum([]byte(content))))
+
+	return diag.Diagnostics{}
+}
@@ -0,0 +1,65 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceEnvFile(t *testing.T) {
+	projectName, _ := uuid.GenerateUUID()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testAccCheckVercelProjectDestroy(projectName),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+				resource "vercel_project" "new" {
+					name = "%s"
+					git_repository {
+						type = "github"
+						repo = "chronark/terraform-provider-vercel"
+					}
+				}
+
+				data "vercel_env_file" "production" {
+					content = <<-EOT
+						export URL="https://chronark.com" # comment
+						GREETING='hello world'
+					EOT
+					target  = ["production"]
+					type    = "plain"
+				}
+
+				resource "vercel_project_env" "all" {
+					project_id = vercel_project.new.id
+
+					dynamic "variable" {
+						for_each = data.vercel_env_file.production.variable
+						content {
+							key    = variable.value.key
+							value  = variable.value.value
+							type   = variable.value.type
+							target = variable.value.target
+						}
+					}
+				}
+
+				data "vercel_project_env_file" "production" {
+					project_id = vercel_project_env.all.project_id
+					target     = "production"
+				}
+				`, projectName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.vercel_env_file.production", "variables.URL", "https://chronark.com"),
+					resource.TestCheckResourceAttr("vercel_project_env.all", "managed.#", "2"),
+					resource.TestCheckResourceAttr("data.vercel_project_env_file.production", "content", "GREETING=\"hello world\"\nURL=https://chronark.com\n"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,256 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/chronark/terraform-provider-vercel/pkg/vercel"
+	"github.com/chronark/terraform-provider-vercel/pkg/vercel/project"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// projectAttributes are the attributes shared by the `vercel_project` and `vercel_projects` data sources.
+func projectAttributes() map[string]*schema.Schema {
+	computedString := func(description string) *schema.Schema {
+		return &schema.Schema{Description: description, Type: schema.TypeString, Computed: true}
+	}
+
+	return map[string]*schema.Schema{
+		"id":                              computedString("Internal id of this project"),
+		"name":                            computedString("The name of the project."),
+		"account_id":                      computedString("The unique ID of the user or team the project belongs to."),
+		"framework":                       computedString("The framework that is being used for this project."),
+		"build_command":                   computedString("The build command for this project."),
+		"dev_command":                     computedString("The dev command for this project."),
+		"install_command":                 computedString("The install command for this project."),
+		"output_directory":                computedString("The output directory of the project."),
+		"root_directory":                  computedString("The name of a directory or relative path to the source code of your project."),
+		"node_version":                    computedString("The Node.js Version for this project."),
+		"serverless_function_region":      computedString("The region to deploy Serverless Functions in this project."),
+		"command_for_ignoring_build_step": computedString("The command that decides whether a commit is built."),
+		"function_default_memory_type":    computedString("The memory and cpu of the serverless functions of this project."),
+		"git_fork_protection": {
+			Description: "Whether pull requests from forks require an authorization by a team member before they are deployed.",
+			Type:        schema.TypeBool,
+			Computed:    true,
+		},
+		"function_default_timeout": {
+			Description: "The maximum duration of the serverless functions of this project in seconds.",
+			Type:        schema.TypeInt,
+			Computed:    true,
+		},
+		"function_default_regions": {
+			Description: "The regions the serverless functions of this project are deployed to.",
+			Type:        schema.TypeList,
+			Computed:    true,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+		},
+		"directory_listing": {
+			Description: "Whether a listing of the files is shown for paths that point to a directory without an index file.",
+			Type:        schema.TypeBool,
+			Computed:    true,
+		},
+		"auto_expose_system_envs": {
+			Description: "Whether the system environment variables are exposed to builds and functions automatically.",
+			Type:        schema.TypeBool,
+			Computed:    true,
+		},
+		"source_files_outside_root_directory": {
+			Description: "Whether files outside of the root directory are available to builds.",
+			Type:        schema.TypeBool,
+			Computed:    true,
+		},
+		"skew_protection_max_age": {
+			Description: "How long previous deployments keep serving clients that were loaded from them in seconds, 0 if skew protection is disabled.",
+			Type:        schema.TypeInt,
+			Computed:    true,
+		},
+		"public_source": {
+			Description: "Specifies whether the source code and logs of the deployments for this project are public or not.",
+			Type:        schema.TypeBool,
+			Computed:    true,
+		},
+		"created_at": {
+			Description: "A number containing the date when the project was created in milliseconds.",
+			Type:        schema.TypeInt,
+			Computed:    true,
+		},
+		"updated_at": {
+			Description: "A number containing the date when the project was updated in milliseconds.",
+			Type:        schema.TypeInt,
+			Computed:    true,
+		},
+		"link": {
+			Description: "The git repository connected to the project. Empty if the project is not connected to a repository.",
+			Type:        schema.TypeList,
+			Computed:    true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"type":              computedString("The git provider of the repository, `github`, `gitlab` or `bitbucket`."),
+					"repo":              computedString("The full name of the repository, e.g. `chronark/terraform-provider-vercel`."),
+					"production_branch": computedString("The branch that is deployed to production."),
+					"repo_id": {
+						Description: "The id of the repository at the git provider.",
+						Type:        schema.TypeInt,
+						Computed:    true,
+					},
+				},
+			},
+		},
+		"alias": {
+			Description: "The domains assigned to the project.",
+			Type:        schema.TypeList,
+			Computed:    true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"domain":     computedString("The domain name."),
+					"redirect":   computedString("The domain this domain redirects to."),
+					"git_branch": computedString("The git branch the domain is assigned to, empty for production."),
+				},
+			},
+		},
+		"env": {
+			Description: "The environment variables of the project. Values are not included.",
+			Type:        schema.TypeList,
+			Computed:    true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"id":         computedString("The unique identifier of the environment variable."),
+					"key":        computedString("The name of the environment variable."),
+					"type":       computedString("The type of the environment variable."),
+					"git_branch": computedString("The git branch the variable is restricted to."),
+					"target": {
+						Description: "The environments the variable is available in.",
+						Type:        schema.TypeList,
+						Computed:    true,
+						Elem: &schema.Schema{
+							Type: schema.TypeString,
+						},
+					},
+					"updated_at": {
+						Description: "A number containing the date when the variable was updated in milliseconds.",
+						Type:        schema.TypeInt,
+						Computed:    true,
+					},
+				},
+			},
+		},
+	}
+}
+
+func flattenProject(p project.Project) map[string]interface{} {
+	link := []interface{}{}
+	if p.Link.Type != "" {
+		link = append(link, map[string]interface{}{
+			"type":              p.Link.Type,
+			"repo":              repoFromLink(p),
+			"production_branch": p.Link.ProductionBranch,
+			"repo_id":           p.Link.RepoID,
+		})
+	}
+
+	aliases := make([]interface{}, len(p.Aliases))
+	for i, a := range p.Aliases {
+		aliases[i] = map[string]interface{}{
+			"domain":     a.Domain,
+			"redirect":   a.Redirect,
+			"git_branch": a.GitBranch,
+		}
+	}
+
+	envs := make([]interface{}, len(p.Env))
+	for i, e := range p.Env {
+		envs[i] = map[string]interface{}{
+			"id":         e.ID,
+			"key":        e.Key,
+			"type":       e.Type,
+			"git_branch": e.GitBranch,
+			"target":     e.Target,
+			"updated_at": e.UpdatedAt,
+		}
+	}
+
+	return map[string]interface{}{
+		"id":                                  p.ID,
+		"name":                                p.Name,
+		"account_id":                          p.AccountID,
+		"framework":                           p.Framework,
+		"build_command":                       p.BuildCommand,
+		"dev_command":                         p.DevCommand,
+		"install_command":                     p.InstallCommand,
+		"output_directory":                    p.OutputDirectory,
+		"root_directory":                      p.RootDirectory,
+		"node_version":                        p.NodeVersion,
+		"serverless_function_region":          p.ServerlessFunctionRegion,
+		"public_source":                       p.PublicSource,
+		"command_for_ignoring_build_step":     p.CommandForIgnoringBuildStep,
+		"git_fork_protection":                 p.GitForkProtection,
+		"function_default_memory_type":        p.ResourceConfig.FunctionDefaultMemoryType,
+		"function_default_timeout":            p.ResourceConfig.FunctionDefaultTimeout,
+		"function_default_regions":            p.ResourceConfig.FunctionDefaultRegions,
+		"directory_listing":                   p.DirectoryListing,
+		"auto_expose_system_envs":             p.AutoExposeSystemEnvs,
+		"source_files_outside_root_directory": p.SourceFilesOutsideRootDirectory,
+		"skew_protection_max_age":             p.SkewProtectionMaxAge,
+		"created_at":                          p.CreatedAt,
+		"updated_at":                          p.UpdatedAt,
+		"link":                                link,
+		"alias":                               aliases,
+		"env":                                 envs,
+	}
+}
+
+func dataSourceProject() *schema.Resource {
+	s := projectAttributes()
+
+	s["id"].Description = "The id of the project. Either `id` or `name` must be set."
+	s["id"].Optional = true
+	s["id"].ExactlyOneOf = []string{"id", "name"}
+	s["name"].Description = "The name of the project. Either `id` or `name` must be set."
+	s["name"].Optional = true
+	s["name"].ExactlyOneOf = []string{"id", "name"}
+	s["team_id"] = &schema.Schema{
+		Description: "By default, you can access resources contained within your own user account. To access resources owned by a team, you can pass in the team ID. Defaults to the team configured in the provider.",
+		Type:        schema.TypeString,
+		Optional:    true,
+		Computed:    true,
+	}
+
+	return &schema.Resource{
+		Description: "Retrieves an existing project by its id or name. https://vercel.com/docs/api#endpoints/projects/find-a-project-by-id-or-name",
+		ReadContext: dataSourceProjectRead,
+		Schema:      s,
+	}
+}
+
+func dataSourceProjectRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*vercel.Client)
+
+	teamId := inheritTeamID(d.Get("team_id").(string), meta)
+	err := d.Set("team_id", teamId)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Vercel accepts both the id and the name of a project
+	idOrName := d.Get("id").(string)
+	if idOrName == "" {
+		idOrName = d.Get("name").(string)
+	}
+
+	p, err := client.Project.Read(ctx, idOrName, teamId)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("unable to find project %s: %w", idOrName, err))
+	}
+
+	for key, value := range flattenProject(p) {
+		err = d.Set(key, value)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	d.SetId(p.ID)
+
+	return diag.Diagnostics{}
+}
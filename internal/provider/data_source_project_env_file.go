@@ -0,0 +1,135 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/chronark/terraform-provider-vercel/pkg/vercel"
+	"github.com/chronark/terraform-provider-vercel/pkg/vercel/env"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceProjectEnvFile() *schema.Resource {
+	return &schema.Resource{
+		Description: "Renders the environment variables of a project for one target as a dotenv file.",
+		ReadContext: dataSourceProjectEnvFileRead,
+		Schema: map[string]*schema.Schema{
+			"project_id": {
+				Description: "The unique project identifier.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"team_id": {
+				Description: "By default, you can access resources contained within your own user account. To access resources owned by a team, you can pass in the team ID. Defaults to the team configured in the provider.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+			},
+			"target": {
+				Description:  "The target whose variables are rendered, one of `development`, `preview` or `production`.",
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice(envTargets, false),
+			},
+			"git_branch": {
+				Description: "Also render the variables of this Git branch, they take precedence over the variables without a branch.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"content": {
+				Description: "The variables as dotenv file, sorted by key.",
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+			},
+			"skipped_keys": {
+				Description: "The keys of `sensitive` variables, they are not part of `content` because vercel never returns their value.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+func dataSourceProjectEnvFileRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*vercel.Client)
+
+	teamId := inheritTeamID(d.Get("team_id").(string), meta)
+	err := d.Set("team_id", teamId)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	projectID := d.Get("project_id").(string)
+	target := d.Get("target").(string)
+	gitBranch := d.Get("git_branch").(string)
+
+	envs, err := client.Env.Read(ctx, projectID, teamId)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Variables of the branch are added last, so they overwrite the general ones
+	sort.SliceStable(envs, func(i, j int) bool { return envs[i].GitBranch == "" && envs[j].GitBranch != "" })
+
+	values := map[string]string{}
+	skipped := map[string]bool{}
+	for _, e := range envs {
+		if (e.GitBranch != "" && e.GitBranch != gitBranch) || !containsString(e.Target, target) {
+			continue
+		}
+		if e.Type == env.TypeEncrypted {
+			e, err = client.Env.Get(ctx, projectID, e.ID, true, teamId)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+		}
+		if !e.HasValue() {
+			skipped[e.Key] = true
+			delete(values, e.Key)
+			continue
+		}
+		delete(skipped, e.Key)
+		values[e.Key] = e.Value
+	}
+
+	entries := []env.DotenvEntry{}
+	for key, value := range values {
+		entries = append(entries, env.DotenvEntry{Key: key, Value: value})
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Key < entries[j].Key })
+
+	skippedKeys := []string{}
+	for key := range skipped {
+		skippedKeys = append(skippedKeys, key)
+	}
+	sort.Strings(skippedKeys)
+
+	err = d.Set("content", env.RenderDotenv(entries))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	err = d.Set("skipped_keys", skippedKeys)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s/%s/%s", teamId, projectID, target, gitBranch))
+
+	return diag.Diagnostics{}
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,55 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceProject(t *testing.T) {
+	projectName, _ := uuid.GenerateUUID()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testAccCheckVercelProjectDestroy(projectName),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceProjectConfig(projectName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.vercel_project.by_name", "id", "vercel_project.new", "id"),
+					resource.TestCheckResourceAttr("data.vercel_project.by_name", "link.0.repo", "chronark/terraform-provider-vercel"),
+					resource.TestCheckResourceAttrPair("data.vercel_project.by_id", "name", "vercel_project.new", "name"),
+					resource.TestCheckResourceAttr("data.vercel_projects.prefixed", "projects.#", "1"),
+					resource.TestCheckResourceAttrPair("data.vercel_projects.prefixed", "projects.0.id", "vercel_project.new", "id"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceProjectConfig(name string) string {
+	return fmt.Sprintf(`
+	resource "vercel_project" "new" {
+		name = "%s"
+		git_repository {
+			type = "github"
+			repo = "chronark/terraform-provider-vercel"
+		}
+	}
+
+	data "vercel_project" "by_name" {
+		name = vercel_project.new.name
+	}
+
+	data "vercel_project" "by_id" {
+		id = vercel_project.new.id
+	}
+
+	data "vercel_projects" "prefixed" {
+		name_prefix = vercel_project.new.name
+	}
+	`, name)
+}
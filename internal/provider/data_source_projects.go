@@ -0,0 +1,81 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/chronark/terraform-provider-vercel/pkg/vercel"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceProjects() *schema.Resource {
+	return &schema.Resource{
+		Description: "Retrieves all projects of a user or team, optionally filtered. https://vercel.com/docs/api#endpoints/projects/get-all-projects",
+		ReadContext: dataSourceProjectsRead,
+		Schema: map[string]*schema.Schema{
+			"team_id": {
+				Description: "By default, you can access resources contained within your own user account. To access resources owned by a team, you can pass in the team ID. Defaults to the team configured in the provider.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+			},
+			"name_prefix": {
+				Description: "Only return projects whose name starts with this prefix.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"framework": {
+				Description: "Only return projects using this framework, e.g. `nextjs`.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"projects": {
+				Description: "The matching projects, sorted by name.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: projectAttributes(),
+				},
+			},
+		},
+	}
+}
+
+func dataSourceProjectsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*vercel.Client)
+
+	teamId := inheritTeamID(d.Get("team_id").(string), meta)
+	err := d.Set("team_id", teamId)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	prefix := d.Get("name_prefix").(string)
+	framework := d.Get("framework").(string)
+
+	// The search of vercel matches anywhere in the name, the prefix is checked afterwards
+	projects, err := client.Project.List(ctx, prefix, teamId)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	sort.Slice(projects, func(i, j int) bool { return projects[i].Name < projects[j].Name })
+
+	flattened := []interface{}{}
+	for _, p := range projects {
+		if !strings.HasPrefix(p.Name, prefix) || (framework != "" && p.Framework != framework) {
+			continue
+		}
+		flattened = append(flattened, flattenProject(p))
+	}
+	err = d.Set("projects", flattened)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s/%s", teamId, prefix, framework))
+
+	return diag.Diagnostics{}
+}
@@ -47,7 +47,7 @@ func dataSourceTeamRead(ctx context.Context, d *schema.ResourceData, meta interf
 
 	slug := d.Get("slug").(string)
 
-	team, err := client.Team.Read(slug)
+	team, err := client.Team.Read(ctx, slug)
 	if err != nil {
 		return diag.FromErr(err)
 	}
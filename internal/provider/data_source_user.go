@@ -71,7 +71,7 @@ func dataSourceUserRead(ctx context.Context, d *schema.ResourceData, meta interf
 
 	client := meta.(*vercel.Client)
 
-	user, err := client.User.Read()
+	user, err := client.User.Read(ctx)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -0,0 +1,22 @@
+package provider
+
+import (
+	"log"
+
+	"github.com/chronark/terraform-provider-vercel/pkg/vercel/httpApi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// removedOutsideTerraform checks whether err means the object behind d was deleted outside of terraform.
+// If so the resource is removed from the state, so the next plan recreates it instead of failing.
+// Objects that were just created are never removed, a missing object right after creation is a real error.
+func removedOutsideTerraform(d *schema.ResourceData, err error, kind string) bool {
+	if !httpApi.IsNotFound(err) || d.IsNewResource() {
+		return false
+	}
+
+	log.Printf("[WARN] %s %s no longer exists, removing it from state: %s", kind, d.Id(), err)
+	d.SetId("")
+
+	return true
+}
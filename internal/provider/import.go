@@ -0,0 +1,27 @@
+package provider
+
+import (
+	"fmt"
+	"strings"
+)
+
+// splitImportID splits an import ID of the form `[team_id/]part_1/.../part_n` into the optional team ID
+// and the n remaining parts. `format` is only used to explain the expected format when the ID is invalid.
+func splitImportID(id string, n int, format string) (teamID string, parts []string, err error) {
+	parts = strings.Split(id, "/")
+
+	for _, part := range parts {
+		if part == "" {
+			return "", nil, fmt.Errorf("invalid import ID %q, expected %s", id, format)
+		}
+	}
+
+	switch len(parts) {
+	case n:
+		return "", parts, nil
+	case n + 1:
+		return parts[0], parts[1:], nil
+	default:
+		return "", nil, fmt.Errorf("invalid import ID %q, expected %s", id, format)
+	}
+}
@@ -0,0 +1,24 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitImportID(t *testing.T) {
+	teamID, parts, err := splitImportID("prj_123/env_456", 2, "`[team_id/]project_id/env_id`")
+	require.NoError(t, err)
+	require.Equal(t, "", teamID)
+	require.Equal(t, []string{"prj_123", "env_456"}, parts)
+
+	teamID, parts, err = splitImportID("team_abc/prj_123/env_456", 2, "`[team_id/]project_id/env_id`")
+	require.NoError(t, err)
+	require.Equal(t, "team_abc", teamID)
+	require.Equal(t, []string{"prj_123", "env_456"}, parts)
+
+	for _, id := range []string{"", "prj_123", "a/b/c/d", "prj_123/", "/env_456"} {
+		_, _, err = splitImportID(id, 2, "`[team_id/]project_id/env_id`")
+		require.Error(t, err, id)
+	}
+}
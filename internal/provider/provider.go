@@ -3,10 +3,14 @@ package provider
 import (
 	"context"
 	"fmt"
+	"io/ioutil"
+	"time"
 
 	"github.com/chronark/terraform-provider-vercel/pkg/vercel"
+	"github.com/chronark/terraform-provider-vercel/pkg/vercel/httpApi"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func init() {
@@ -35,19 +39,84 @@ func New(version string) func() *schema.Provider {
 					Sensitive:   true,
 					DefaultFunc: schema.EnvDefaultFunc("VERCEL_TOKEN", nil),
 				},
+				"team_id": {
+					Description:   "The team that resources are created in when they do not set `team_id` themselves. Use provider aliases to manage resources of multiple teams. Can also be set via `VERCEL_TEAM_ID`.",
+					Type:          schema.TypeString,
+					Optional:      true,
+					DefaultFunc:   schema.EnvDefaultFunc("VERCEL_TEAM_ID", ""),
+					ConflictsWith: []string{"team_slug"},
+				},
+				"team_slug": {
+					Description:   "Same as `team_id` but the team is looked up by its slug. Can also be set via `VERCEL_TEAM_SLUG`.",
+					Type:          schema.TypeString,
+					Optional:      true,
+					DefaultFunc:   schema.EnvDefaultFunc("VERCEL_TEAM_SLUG", ""),
+					ConflictsWith: []string{"team_id"},
+				},
+				"max_retries": {
+					Description:  "How often a request is retried when vercel responds with `429` or a server error. Only requests that are safe to replay are retried. Defaults to `3`.",
+					Type:         schema.TypeInt,
+					Optional:     true,
+					DefaultFunc:  schema.EnvDefaultFunc("VERCEL_MAX_RETRIES", 3),
+					ValidateFunc: validation.IntAtLeast(0),
+				},
+				"rate_limit_rps": {
+					Description:  "The maximum number of requests per second sent to vercel. Set to `0` to disable client side rate limiting. Defaults to `1.25`.",
+					Type:         schema.TypeFloat,
+					Optional:     true,
+					DefaultFunc:  schema.EnvDefaultFunc("VERCEL_RATE_LIMIT_RPS", 1.25),
+					ValidateFunc: validation.FloatAtLeast(0),
+				},
+				"api_url": {
+					Description:  "The url of the vercel api. Can point to a proxy or a local stand-in of the api. Can also be set via `VERCEL_API_URL`. Defaults to `https://api.vercel.com`.",
+					Type:         schema.TypeString,
+					Optional:     true,
+					DefaultFunc:  schema.EnvDefaultFunc("VERCEL_API_URL", "https://api.vercel.com"),
+					ValidateFunc: validation.IsURLWithScheme([]string{"http", "https"}),
+				},
+				"ca_cert_file": {
+					Description: "Path to a PEM encoded CA bundle that is trusted in addition to the system's certificates. Can also be set via `VERCEL_CA_CERT_FILE`.",
+					Type:        schema.TypeString,
+					Optional:    true,
+					DefaultFunc: schema.EnvDefaultFunc("VERCEL_CA_CERT_FILE", ""),
+				},
+				"http_proxy": {
+					Description:  "The proxy all requests to vercel are sent through. Defaults to the standard `HTTPS_PROXY` and `NO_PROXY` environment variables.",
+					Type:         schema.TypeString,
+					Optional:     true,
+					ValidateFunc: validation.IsURLWithScheme([]string{"http", "https", "socks5"}),
+				},
+				"request_timeout": {
+					Description:  "The timeout of a single request in seconds, file uploads are only limited while waiting for the response. Set to `0` to disable the timeout. Defaults to `60`.",
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Default:      60,
+					ValidateFunc: validation.IntAtLeast(0),
+				},
 			},
 			DataSourcesMap: map[string]*schema.Resource{
-				"vercel_user": dataSourceUser(),
-				"vercel_team": dataSourceTeam(),
+				"vercel_user":             dataSourceUser(),
+				"vercel_team":             dataSourceTeam(),
+				"vercel_deployment":       dataSourceDeployment(),
+				"vercel_deployments":      dataSourceDeployments(),
+				"vercel_project":          dataSourceProject(),
+				"vercel_projects":         dataSourceProjects(),
+				"vercel_dns_zone_file":    dataSourceDNSZoneFile(),
+				"vercel_env_file":         dataSourceEnvFile(),
+				"vercel_project_env_file": dataSourceProjectEnvFile(),
 			},
 			ResourcesMap: map[string]*schema.Resource{
-				"vercel_env":            resourceEnv(),
-				"vercel_project":        resourceProject(),
-				"vercel_project_domain": resourceProjectDomain(),
-				"vercel_secret":         resourceSecret(),
-				"vercel_domain":         resourceDomain(),
-				"vercel_dns":            resourceDNS(),
-				"vercel_alias":          resourceAlias(),
+				"vercel_env":                   resourceEnv(),
+				"vercel_project_env":           resourceProjectEnv(),
+				"vercel_project":               resourceProject(),
+				"vercel_project_domain":        resourceProjectDomain(),
+				"vercel_project_domain_verify": resourceProjectDomainVerify(),
+				"vercel_secret":                resourceSecret(),
+				"vercel_domain":                resourceDomain(),
+				"vercel_dns":                   resourceDNS(),
+				"vercel_dns_zone":              resourceDNSZone(),
+				"vercel_alias":                 resourceAlias(),
+				"vercel_deployment":            resourceDeployment(),
 			},
 		}
 
@@ -65,7 +134,34 @@ func configure(version string, p *schema.Provider) func(context.Context, *schema
 			return nil, diag.FromErr(fmt.Errorf("vercel token is not set, set manually or via `VERCEL_TOKEN` "))
 		}
 
-		client := vercel.New(token)
+		config := httpApi.DefaultConfig()
+		config.MaxRetries = d.Get("max_retries").(int)
+		config.RateLimitRPS = d.Get("rate_limit_rps").(float64)
+		config.BaseURL = d.Get("api_url").(string)
+		config.ProxyURL = d.Get("http_proxy").(string)
+		config.Timeout = time.Duration(d.Get("request_timeout").(int)) * time.Second
+
+		if path := d.Get("ca_cert_file").(string); path != "" {
+			pem, err := ioutil.ReadFile(path)
+			if err != nil {
+				return nil, diag.FromErr(fmt.Errorf("unable to read CA bundle: %w", err))
+			}
+			config.CACertPEM = pem
+		}
+
+		client, err := vercel.New(token, config)
+		if err != nil {
+			return nil, diag.FromErr(err)
+		}
+		client.TeamID = d.Get("team_id").(string)
+
+		if slug := d.Get("team_slug").(string); slug != "" {
+			team, err := client.Team.Read(ctx, slug)
+			if err != nil {
+				return nil, diag.FromErr(fmt.Errorf("unable to resolve team slug %q: %w", slug, err))
+			}
+			client.TeamID = team.Id
+		}
 
 		return client, diag.Diagnostics{}
 	}
@@ -1,6 +1,10 @@
 package provider
 
 import (
+	"github.com/chronark/terraform-provider-vercel/pkg/vercel"
+	"github.com/chronark/terraform-provider-vercel/pkg/vercel/fake"
+	"github.com/chronark/terraform-provider-vercel/pkg/vercel/httpApi"
+	"github.com/chronark/terraform-provider-vercel/pkg/vercel/user"
 	"github.com/stretchr/testify/require"
 	"os"
 	"testing"
@@ -14,12 +18,56 @@ var providerFactories = map[string]func() (*schema.Provider, error){
 	},
 }
 
+// testAccFake is true when the acceptance tests run against the in-memory fake.
+var testAccFake bool
+
+// TestMain runs the acceptance tests against an in-memory fake of the vercel api unless `VERCEL_TOKEN` is set,
+// in which case they run against the real account of that token.
+func TestMain(m *testing.M) {
+	if os.Getenv("VERCEL_TOKEN") != "" {
+		os.Exit(m.Run())
+	}
+
+	testAccFake = true
+	srv := fake.NewServer("fake-token")
+	srv.SetUser(user.User{UID: "usr_fake", Username: "chronark", Email: "chronark@example.com"})
+
+	os.Setenv("VERCEL_TOKEN", srv.Token)
+	os.Setenv("VERCEL_API_URL", srv.URL)
+	os.Setenv("VERCEL_RATE_LIMIT_RPS", "0")
+
+	code := m.Run()
+	srv.Close()
+	os.Exit(code)
+}
+
 func TestProvider(t *testing.T) {
 	if err := New("dev")().InternalValidate(); err != nil {
 		t.Fatalf("Unable to create new provider: %s", err)
 	}
 }
 
+// testAccClient returns a client to verify the results of acceptance tests against vercel.
+func testAccClient() *vercel.Client {
+	config := httpApi.DefaultConfig()
+	if url := os.Getenv("VERCEL_API_URL"); url != "" {
+		config.BaseURL = url
+	}
+
+	client, err := vercel.New(os.Getenv("VERCEL_TOKEN"), config)
+	if err != nil {
+		panic(err)
+	}
+	return client
+}
+
 func testAccPreCheck(t *testing.T) {
 	require.NotEmpty(t, os.Getenv("VERCEL_TOKEN"))
 }
+
+// testAccFakeOnly skips tests that would spend money or need domains we do not own on a real account.
+func testAccFakeOnly(t *testing.T) {
+	if !testAccFake {
+		t.Skip("only runs against the fake vercel api")
+	}
+}
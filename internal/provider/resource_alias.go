@@ -18,6 +18,9 @@ func resourceAlias() *schema.Resource {
 		ReadContext:   resourceAliasRead,
 		UpdateContext: resourceAliasUpdate,
 		DeleteContext: resourceAliasDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceAliasImport,
+		},
 
 		Schema: map[string]*schema.Schema{
 			"project_id": {
@@ -32,11 +35,11 @@ func resourceAlias() *schema.Resource {
 				Computed:    true,
 			},
 			"team_id": {
-				Description: "By default, you can access resources contained within your own user account. To access resources owned by a team, you can pass in the team ID",
+				Description: "By default, you can access resources contained within your own user account. To access resources owned by a team, you can pass in the team ID. Defaults to the team configured in the provider.",
 				Type:        schema.TypeString,
 				Optional:    true,
+				Computed:    true,
 				ForceNew:    true,
-				Default:     "",
 			},
 			"domain": {
 				Description: "The name of the production domain.",
@@ -58,7 +61,11 @@ func resourceAliasCreate(ctx context.Context, d *schema.ResourceData, meta inter
 
 	projectId := d.Get("project_id").(string)
 	domain := d.Get("domain").(string)
-	teamId := d.Get("team_id").(string)
+	teamId := inheritTeamID(d.Get("team_id").(string), meta)
+	err := d.Set("team_id", teamId)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	payload := alias.CreateOrUpdateAlias{
 		Domain: domain,
@@ -67,7 +74,7 @@ func resourceAliasCreate(ctx context.Context, d *schema.ResourceData, meta inter
 	if set {
 		payload.Redirect = redirect.(string)
 	}
-	err := client.Alias.Create(projectId, payload, teamId)
+	err = client.Alias.Create(ctx, projectId, payload, teamId)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -80,9 +87,14 @@ func resourceAliasRead(ctx context.Context, d *schema.ResourceData, meta interfa
 	projectId := d.Get("project_id").(string)
 	domain := d.Get("domain").(string)
 	teamId := d.Get("team_id").(string)
-	alias, err := client.Alias.Read(
-		projectId, domain, teamId,
-	)
+	alias, err := client.Alias.Read(ctx, projectId, domain, teamId)
+	if err != nil {
+		if removedOutsideTerraform(d, err, "alias") {
+			return diag.Diagnostics{}
+		}
+		return diag.FromErr(err)
+	}
+	err = d.Set("project_id", alias.ProjectId)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -111,7 +123,7 @@ func resourceAliasUpdate(ctx context.Context, d *schema.ResourceData, meta inter
 		payload.Redirect = d.Get("redirect").(string)
 	}
 
-	err := client.Alias.Update(d.Get("project_id").(string), payload, d.Get("team_id").(string))
+	err := client.Alias.Update(ctx, d.Get("project_id").(string), payload, d.Get("team_id").(string))
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -123,10 +135,33 @@ func resourceAliasDelete(ctx context.Context, d *schema.ResourceData, meta inter
 
 	client := meta.(*vercel.Client)
 
-	err := client.Alias.Delete(d.Get("project_id").(string), d.Get("domain").(string), d.Get("team_id").(string))
+	err := client.Alias.Delete(ctx, d.Get("project_id").(string), d.Get("domain").(string), d.Get("team_id").(string))
 	if err != nil {
 		return diag.FromErr(err)
 	}
 	d.SetId("")
 	return diag.Diagnostics{}
 }
+
+func resourceAliasImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	teamID, parts, err := splitImportID(d.Id(), 2, "`[team_id/]project_id/domain`")
+	if err != nil {
+		return nil, err
+	}
+
+	err = d.Set("team_id", inheritTeamID(teamID, meta))
+	if err != nil {
+		return nil, err
+	}
+	err = d.Set("project_id", parts[0])
+	if err != nil {
+		return nil, err
+	}
+	err = d.Set("domain", parts[1])
+	if err != nil {
+		return nil, err
+	}
+	d.SetId(fmt.Sprintf("%s-%s", parts[0], parts[1]))
+
+	return []*schema.ResourceData{d}, nil
+}
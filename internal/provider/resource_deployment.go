@@ -0,0 +1,264 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/chronark/terraform-provider-vercel/pkg/vercel"
+	"github.com/chronark/terraform-provider-vercel/pkg/vercel/deployment"
+	"github.com/chronark/terraform-provider-vercel/pkg/vercel/httpApi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceDeployment() *schema.Resource {
+	return &schema.Resource{
+		Description: "https://vercel.com/docs/api#endpoints/deployments",
+
+		CreateContext: resourceDeploymentCreate,
+		ReadContext:   resourceDeploymentRead,
+		UpdateContext: resourceDeploymentUpdate,
+		DeleteContext: resourceDeploymentDelete,
+		CustomizeDiff: resourceDeploymentCustomizeDiff,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(15 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Description: "The unique identifier of the deployment.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"team_id": {
+				Description: "By default, you can access resources contained within your own user account. To access resources owned by a team, you can pass in the team ID. Defaults to the team configured in the provider.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+			},
+			"name": {
+				Description: "The name of the deployment, it is used as prefix of the generated url.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"project_id": {
+				Description: "The ID of the project the deployment belongs to. Without a project, vercel creates one with the name of the deployment.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+			},
+			"directory": {
+				Description: "The local directory that is uploaded. `.git`, `.vercel` and `node_modules` directories are skipped. Moving the directory does not redeploy, only changes to its content do.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"production": {
+				Description: "Whether the deployment targets production. Otherwise a preview deployment is created.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				ForceNew:    true,
+			},
+			"meta": {
+				Description: "Metadata attached to the deployment.",
+				Type:        schema.TypeMap,
+				Optional:    true,
+				ForceNew:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+
+			"content_hash": {
+				Description: "A sha1 digest over the names and contents of all files in the directory. A new deployment is created whenever it changes.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"url": {
+				Description: "The url of the deployment, without protocol.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"ready_state": {
+				Description: "The state of the build, one of `QUEUED`, `INITIALIZING`, `BUILDING`, `READY`, `ERROR` or `CANCELED`.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"created_at": {
+				Description: "A number containing the date when the deployment was created in milliseconds.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// resourceDeploymentCustomizeDiff hashes the directory during planning, so a changed file shows up as a
+// replacement of the deployment.
+func resourceDeploymentCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("directory") {
+		return d.SetNewComputed("content_hash")
+	}
+
+	files, err := deployment.ReadDir(d.Get("directory").(string))
+	if err != nil {
+		return err
+	}
+
+	hash := deployment.Hash(files)
+	if hash == d.Get("content_hash").(string) {
+		return nil
+	}
+
+	err = d.SetNew("content_hash", hash)
+	if err != nil {
+		return err
+	}
+	if d.Id() == "" {
+		return nil
+	}
+	return d.ForceNew("content_hash")
+}
+
+func resourceDeploymentCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*vercel.Client)
+
+	teamId := inheritTeamID(d.Get("team_id").(string), meta)
+	err := d.Set("team_id", teamId)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	files, err := deployment.ReadDir(d.Get("directory").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	payload := deployment.CreateDeployment{
+		Name:    d.Get("name").(string),
+		Project: d.Get("project_id").(string),
+		Meta:    map[string]string{},
+	}
+	if d.Get("production").(bool) {
+		payload.Target = "production"
+	}
+	for key, value := range d.Get("meta").(map[string]interface{}) {
+		payload.Meta[key] = value.(string)
+	}
+
+	created, err := client.Deployment.Create(ctx, payload, files, teamId)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Store the id right away, a deployment that fails to build is tainted instead of being forgotten
+	d.SetId(created.ID)
+	err = d.Set("content_hash", deployment.Hash(files))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = waitForDeployment(ctx, client, created.ID, teamId, d.Timeout(schema.TimeoutCreate))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceDeploymentRead(ctx, d, meta)
+}
+
+// waitForDeployment polls the deployment until the build is finished.
+func waitForDeployment(ctx context.Context, client *vercel.Client, id string, teamId string, timeout time.Duration) error {
+	conf := &resource.StateChangeConf{
+		Pending: []string{deployment.StateQueued, deployment.StateInitializing, deployment.StateBuilding},
+		Target:  []string{deployment.StateReady},
+		Refresh: func() (interface{}, string, error) {
+			d, err := client.Deployment.Read(ctx, id, teamId)
+			if err != nil {
+				return nil, "", err
+			}
+			switch d.ReadyState {
+			case deployment.StateError, deployment.StateCanceled:
+				return nil, "", fmt.Errorf("deployment %s finished with state %s: %s", id, d.ReadyState, d.ErrorMessage)
+			}
+			return d, d.ReadyState, nil
+		},
+		Timeout:    timeout,
+		MinTimeout: 2 * time.Second,
+	}
+
+	_, err := conf.WaitForStateContext(ctx)
+	return err
+}
+
+func resourceDeploymentRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*vercel.Client)
+
+	deployment, err := client.Deployment.Read(ctx, d.Id(), d.Get("team_id").(string))
+	if err != nil {
+		if removedOutsideTerraform(d, err, "deployment") {
+			return diag.Diagnostics{}
+		}
+		return diag.FromErr(err)
+	}
+
+	err = d.Set("name", deployment.Name)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	err = d.Set("project_id", deployment.ProjectID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	err = d.Set("production", deployment.Target == "production")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	err = d.Set("url", deployment.URL)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	err = d.Set("ready_state", deployment.ReadyState)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	err = d.Set("created_at", deployment.CreatedAt)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	// Vercel adds meta of its own, only the configured keys are tracked
+	configuredMeta := map[string]string{}
+	for key := range d.Get("meta").(map[string]interface{}) {
+		if value, ok := deployment.Meta[key]; ok {
+			configuredMeta[key] = value
+		}
+	}
+	err = d.Set("meta", configuredMeta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diag.Diagnostics{}
+}
+
+// Only the directory can change without a new deployment, there is nothing to send to vercel.
+func resourceDeploymentUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return resourceDeploymentRead(ctx, d, meta)
+}
+
+func resourceDeploymentDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*vercel.Client)
+
+	// A deployment that was already deleted outside of terraform is gone as well
+	err := client.Deployment.Delete(ctx, d.Id(), d.Get("team_id").(string))
+	if err != nil && !httpApi.IsNotFound(err) {
+		return diag.FromErr(err)
+	}
+	d.SetId("")
+	return diag.Diagnostics{}
+}
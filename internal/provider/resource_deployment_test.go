@@ -0,0 +1,137 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/chronark/terraform-provider-vercel/pkg/vercel/deployment"
+	"github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccVercelDeployment(t *testing.T) {
+
+	name, _ := uuid.GenerateUUID()
+	dir, err := ioutil.TempDir("", "deployment")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	var (
+		// Holds the deployment fetched from vercel when we create it at the beginning
+		actualDeploymentAfterCreation deployment.Deployment
+
+		// Changing a file results in a new deployment, so we expect this value to have a different id.
+		actualDeploymentAfterUpdate deployment.Deployment
+	)
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testAccCheckVercelDeploymentDestroy(),
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() { testAccWriteDeploymentFile(t, dir, "<h1>hello</h1>") },
+				Config:    testAccCheckVercelDeploymentConfig(name, dir),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckVercelDeploymentExists("vercel_deployment.new", &actualDeploymentAfterCreation),
+					resource.TestCheckResourceAttr("vercel_deployment.new", "ready_state", deployment.StateReady),
+					resource.TestCheckResourceAttrSet("vercel_deployment.new", "url"),
+					resource.TestCheckResourceAttrSet("vercel_deployment.new", "content_hash"),
+					resource.TestCheckResourceAttr("vercel_deployment.new", "meta.team", "platform"),
+				),
+			},
+			{
+				PreConfig: func() { testAccWriteDeploymentFile(t, dir, "<h1>world</h1>") },
+				Config:    testAccCheckVercelDeploymentConfig(name, dir),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckVercelDeploymentExists("vercel_deployment.new", &actualDeploymentAfterUpdate),
+					testAccCheckDeploymentWasRecreated(&actualDeploymentAfterCreation, &actualDeploymentAfterUpdate),
+				),
+			},
+			{
+				// Nothing changed, so there is nothing to deploy
+				Config:   testAccCheckVercelDeploymentConfig(name, dir),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func testAccWriteDeploymentFile(t *testing.T, dir string, content string) {
+	err := ioutil.WriteFile(filepath.Join(dir, "index.html"), []byte(content), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func testAccCheckDeploymentWasRecreated(d1, d2 *deployment.Deployment) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if d1.ID == d2.ID {
+			return fmt.Errorf("Expected different IDs but they are the same.")
+		}
+		return nil
+	}
+}
+
+// Test whether the deployment was destroyed properly and finishes the job if necessary
+func testAccCheckVercelDeploymentDestroy() resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testAccClient()
+
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type != "vercel_deployment" {
+				continue
+			}
+
+			_, err := client.Deployment.Read(context.Background(), rs.Primary.ID, "")
+			if err == nil {
+				message := "Deployment was not deleted from vercel during terraform destroy."
+				deleteErr := client.Deployment.Delete(context.Background(), rs.Primary.ID, "")
+				if deleteErr != nil {
+					return fmt.Errorf(message+" Automated removal did not succeed. Please manually remove %s. Error: %w", rs.Primary.ID, deleteErr)
+				}
+				return fmt.Errorf(message + " It was removed now.")
+			}
+		}
+		return nil
+	}
+}
+
+func testAccCheckVercelDeploymentConfig(name string, dir string) string {
+	return fmt.Sprintf(`
+	resource "vercel_deployment" "new" {
+		name      = "%s"
+		directory = "%s"
+		meta = {
+			team = "platform"
+		}
+	}
+	`, name, dir)
+}
+
+func testAccCheckVercelDeploymentExists(n string, actual *deployment.Deployment) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+
+		if !ok {
+			return fmt.Errorf("Not found: %s in %+v", n, s.RootModule().Resources)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No deployment set")
+		}
+
+		deployment, err := testAccClient().Deployment.Read(context.Background(), rs.Primary.ID, "")
+		if err != nil {
+			return err
+		}
+		*actual = deployment
+		return nil
+	}
+}
@@ -2,19 +2,27 @@ package provider
 
 import (
 	"context"
+	"fmt"
+
 	"github.com/chronark/terraform-provider-vercel/pkg/vercel"
 	"github.com/chronark/terraform-provider-vercel/pkg/vercel/dns"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceDNS() *schema.Resource {
 	return &schema.Resource{
-		Description: "https://vercel.com/docs/api#endpoints/dns\nCurrently this will only fetch the last 1000 records. Please create an issue if you require more.",
+		Description: "https://vercel.com/docs/api#endpoints/dns",
 
 		CreateContext: resourceDNSCreate,
 		ReadContext:   resourceDNSRead,
+		UpdateContext: resourceDNSUpdate,
 		DeleteContext: resourceDNSDelete,
+		CustomizeDiff: resourceDNSCustomizeDiff,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceDNSImport,
+		},
 
 		Schema: map[string]*schema.Schema{
 			"domain": {
@@ -29,37 +37,80 @@ func resourceDNS() *schema.Resource {
 				Computed:    true,
 			},
 			"team_id": {
-				Description: "By default, you can access resources contained within your own user account. To access resources owned by a team, you can pass in the team ID",
+				Description: "By default, you can access resources contained within your own user account. To access resources owned by a team, you can pass in the team ID. Defaults to the team configured in the provider.",
 				Type:        schema.TypeString,
 				Optional:    true,
+				Computed:    true,
 				ForceNew:    true,
-				Default:     "",
 			},
 			"type": {
-				Description: "The type of record, it could be any valid DNS record.",
-				Type:        schema.TypeString,
-				Required:    true,
-				ForceNew:    true,
+				Description:  "The type of record, one of `A`, `AAAA`, `ALIAS`, `CAA`, `CNAME`, `HTTPS`, `MX`, `NS`, `SRV` or `TXT`.",
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice(dns.Types, false),
 			},
 			"name": {
 				Description: "A subdomain name or an empty string for the root domain.",
 				Type:        schema.TypeString,
 				Required:    true,
-				ForceNew:    true,
 			},
 
 			"value": {
-				Description: "The record value.",
+				Description: "The record value. Required for all types except `SRV`, which uses the `srv` block instead. `CAA` values look like `0 issue \"letsencrypt.org\"`, `HTTPS` values like `1 . alpn=\"h3,h2\"`.",
 				Type:        schema.TypeString,
-				Required:    true,
-				ForceNew:    true,
+				Optional:    true,
+			},
+			"mx_priority": {
+				Description:  "The priority of an `MX` record, required for and only allowed on `MX` records.",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntBetween(0, 65535),
+			},
+			"srv": {
+				Description: "The service an `SRV` record points to, required for and only allowed on `SRV` records.",
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"priority": {
+							Description:  "The priority of the target.",
+							Type:         schema.TypeInt,
+							Required:     true,
+							ValidateFunc: validation.IntBetween(0, 65535),
+						},
+						"weight": {
+							Description:  "The relative weight of targets with the same priority.",
+							Type:         schema.TypeInt,
+							Required:     true,
+							ValidateFunc: validation.IntBetween(0, 65535),
+						},
+						"port": {
+							Description:  "The port the service is running on.",
+							Type:         schema.TypeInt,
+							Required:     true,
+							ValidateFunc: validation.IntBetween(0, 65535),
+						},
+						"target": {
+							Description: "The hostname of the machine providing the service.",
+							Type:        schema.TypeString,
+							Required:    true,
+						},
+					},
+				},
+			},
+			"comment": {
+				Description:  "A note about the record, at most 500 characters.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringLenBetween(0, dns.MaxCommentLength),
 			},
 			"ttl": {
 				Description: "The TTL value. Must be a number between 60 and 2147483647. Default value is 60.",
 				Type:        schema.TypeInt,
 				Optional:    true,
 				Default:     60,
-				ForceNew:    true,
 			},
 			"creator": {
 				Description: "The ID of the user who created the record or system if the record is an automatic record.",
@@ -90,19 +141,68 @@ func resourceDNS() *schema.Resource {
 	}
 }
 
+// recordConfig is implemented by both `schema.ResourceData` and `schema.ResourceDiff`.
+type recordConfig interface {
+	Get(key string) interface{}
+	GetOkExists(key string) (interface{}, bool)
+}
+
+func recordFromConfig(d recordConfig) dns.CreateRecord {
+	record := dns.CreateRecord{
+		Name:    d.Get("name").(string),
+		Type:    d.Get("type").(string),
+		Value:   d.Get("value").(string),
+		TTL:     d.Get("ttl").(int),
+		Comment: d.Get("comment").(string),
+	}
+
+	// A priority of 0 is valid, so the zero value can not tell whether it was configured
+	if priority, ok := d.GetOkExists("mx_priority"); ok {
+		p := priority.(int)
+		record.MXPriority = &p
+	}
+
+	if srv := d.Get("srv").([]interface{}); len(srv) > 0 && srv[0] != nil {
+		block := srv[0].(map[string]interface{})
+		record.SRV = &dns.SRV{
+			Priority: block["priority"].(int),
+			Weight:   block["weight"].(int),
+			Port:     block["port"].(int),
+			Target:   block["target"].(string),
+		}
+	}
+
+	return record
+}
+
+// resourceDNSCustomizeDiff validates the combination of type, value and the type specific fields during planning.
+func resourceDNSCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	for _, key := range []string{"type", "value", "mx_priority", "srv"} {
+		if !d.NewValueKnown(key) {
+			return nil
+		}
+	}
+
+	err := recordFromConfig(d).Validate()
+	if err != nil {
+		return fmt.Errorf("invalid dns record: %w", err)
+	}
+	return nil
+}
+
 func resourceDNSCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 
 	client := meta.(*vercel.Client)
 
-	payload := dns.CreateRecord{
-		Name:  d.Get("name").(string),
-		Type:  d.Get("type").(string),
-		Value: d.Get("value").(string),
-		TTL:   d.Get("ttl").(int),
-	}
+	payload := recordFromConfig(d)
 	domain := d.Get("domain").(string)
-	teamId := d.Get("team_id").(string)
-	dnsId, err := client.DNS.Create(domain, payload, teamId)
+	teamId := inheritTeamID(d.Get("team_id").(string), meta)
+	err := d.Set("team_id", teamId)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	dnsId, err := client.DNS.Create(ctx, domain, payload, teamId)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -115,8 +215,11 @@ func resourceDNSCreate(ctx context.Context, d *schema.ResourceData, meta interfa
 func resourceDNSRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*vercel.Client)
 
-	record, err := client.DNS.Read(d.Get("domain").(string), d.Id(), d.Get("team_id").(string))
+	record, err := client.DNS.Read(ctx, d.Get("domain").(string), d.Id(), d.Get("team_id").(string))
 	if err != nil {
+		if removedOutsideTerraform(d, err, "dns record") {
+			return diag.Diagnostics{}
+		}
 		return diag.FromErr(err)
 	}
 
@@ -132,6 +235,41 @@ func resourceDNSRead(ctx context.Context, d *schema.ResourceData, meta interface
 	if err != nil {
 		return diag.FromErr(err)
 	}
+	err = d.Set("ttl", record.TTL)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	err = d.Set("comment", record.Comment)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// The type specific fields are only set for their type, otherwise they would count as configured
+	switch record.Type {
+	case dns.TypeMX:
+		err = d.Set("mx_priority", record.MXPriority)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	case dns.TypeSRV:
+		srv, err := record.SRV()
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		err = d.Set("srv", []interface{}{map[string]interface{}{
+			"priority": srv.Priority,
+			"weight":   srv.Weight,
+			"port":     srv.Port,
+			"target":   srv.Target,
+		}})
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		err = d.Set("value", "")
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
 	err = d.Set("creator", record.Creator)
 	if err != nil {
 		return diag.FromErr(err)
@@ -156,14 +294,54 @@ func resourceDNSRead(ctx context.Context, d *schema.ResourceData, meta interface
 	return diag.Diagnostics{}
 }
 
+func resourceDNSUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*vercel.Client)
+
+	record := recordFromConfig(d)
+	payload := dns.UpdateRecord{
+		Name:       record.Name,
+		Value:      record.Value,
+		TTL:        record.TTL,
+		MXPriority: record.MXPriority,
+		SRV:        record.SRV,
+		Comment:    record.Comment,
+	}
+
+	err := client.DNS.Update(ctx, d.Id(), payload, d.Get("team_id").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceDNSRead(ctx, d, meta)
+}
+
 func resourceDNSDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 
 	client := meta.(*vercel.Client)
 
-	err := client.DNS.Delete(d.Get("domain").(string), d.Id(), d.Get("team_id").(string))
+	err := client.DNS.Delete(ctx, d.Get("domain").(string), d.Id(), d.Get("team_id").(string))
 	if err != nil {
 		return diag.FromErr(err)
 	}
 	d.SetId("")
 	return diag.Diagnostics{}
 }
+
+func resourceDNSImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	teamID, parts, err := splitImportID(d.Id(), 2, "`[team_id/]domain/record_id`")
+	if err != nil {
+		return nil, err
+	}
+
+	err = d.Set("team_id", inheritTeamID(teamID, meta))
+	if err != nil {
+		return nil, err
+	}
+	err = d.Set("domain", parts[0])
+	if err != nil {
+		return nil, err
+	}
+	d.SetId(parts[1])
+
+	return []*schema.ResourceData{d}, nil
+}
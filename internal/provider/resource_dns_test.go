@@ -0,0 +1,134 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/chronark/terraform-provider-vercel/pkg/vercel/dns"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccVercelDNS(t *testing.T) {
+	domain := "acceptance-test-dns.com"
+
+	var (
+		// Holds the record fetched from vercel when we create it at the beginning
+		actualRecordAfterCreation dns.Record
+
+		// Changing the value or ttl updates the record in place, so we expect the same id.
+		actualRecordAfterUpdate dns.Record
+	)
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckVercelDNSConfig(domain, "1.1.1.1", 60),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckVercelDNSExists("vercel_dns.new", &actualRecordAfterCreation),
+					resource.TestCheckResourceAttr("vercel_dns.new", "value", "1.1.1.1"),
+					resource.TestCheckResourceAttr("vercel_dns.new", "ttl", "60"),
+				),
+			},
+			{
+				Config: testAccCheckVercelDNSConfig(domain, "8.8.8.8", 3600),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckVercelDNSExists("vercel_dns.new", &actualRecordAfterUpdate),
+					resource.TestCheckResourceAttr("vercel_dns.new", "value", "8.8.8.8"),
+					resource.TestCheckResourceAttr("vercel_dns.new", "ttl", "3600"),
+					testAccCheckDNSWasNotRecreated(&actualRecordAfterCreation, &actualRecordAfterUpdate),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckDNSWasNotRecreated(r1, r2 *dns.Record) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if r1.Id != r2.Id {
+			return fmt.Errorf("Expected same IDs but they are not the same.")
+		}
+		return nil
+	}
+}
+
+func testAccCheckVercelDNSConfig(domain string, value string, ttl int) string {
+	return fmt.Sprintf(`
+	resource "vercel_domain" "new" {
+		name = "%s"
+	}
+
+	resource "vercel_dns" "new" {
+		domain = vercel_domain.new.name
+		type   = "A"
+		name   = "www"
+		value  = "%s"
+		ttl    = %d
+	}
+	`, domain, value, ttl)
+}
+
+func testAccCheckVercelDNSExists(n string, actual *dns.Record) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+
+		if !ok {
+			return fmt.Errorf("Not found: %s in %+v", n, s.RootModule().Resources)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No record set")
+		}
+
+		record, err := testAccClient().DNS.Read(context.Background(), rs.Primary.Attributes["domain"], rs.Primary.ID, "")
+		if err != nil {
+			return err
+		}
+		*actual = record
+		return nil
+	}
+}
+
+// Reading the type specific fields back must not produce a diff on the next plan.
+func TestAccVercelDNSTypedRecords(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				resource "vercel_domain" "new" {
+					name = "acceptance-test-dns-types.com"
+				}
+
+				resource "vercel_dns" "mx" {
+					domain      = vercel_domain.new.name
+					type        = "MX"
+					name        = ""
+					value       = "mx1.improvmx.com"
+					mx_priority = 0
+					comment     = "Mail forwarding"
+				}
+
+				resource "vercel_dns" "srv" {
+					domain = vercel_domain.new.name
+					type   = "SRV"
+					name   = "_sip._tcp"
+					srv {
+						priority = 10
+						weight   = 5
+						port     = 5060
+						target   = "sip.acceptance-test-dns-types.com"
+					}
+				}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vercel_dns.mx", "mx_priority", "0"),
+					resource.TestCheckResourceAttr("vercel_dns.srv", "srv.0.port", "5060"),
+				),
+			},
+		},
+	})
+}
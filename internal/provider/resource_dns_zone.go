@@ -0,0 +1,408 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/chronark/terraform-provider-vercel/pkg/vercel"
+	"github.com/chronark/terraform-provider-vercel/pkg/vercel/dns"
+	"github.com/chronark/terraform-provider-vercel/pkg/vercel/httpApi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceDNSZone() *schema.Resource {
+	return &schema.Resource{
+		Description: "Manages all dns records of a domain. Records that are not part of the configuration are deleted, except the records vercel creates automatically. Do not combine this resource with `vercel_dns` for the same domain.",
+
+		CreateContext: resourceDNSZoneCreate,
+		ReadContext:   resourceDNSZoneRead,
+		UpdateContext: resourceDNSZoneUpdate,
+		DeleteContext: resourceDNSZoneDelete,
+		CustomizeDiff: resourceDNSZoneCustomizeDiff,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceDNSZoneImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"domain": {
+				Description: "The domain whose records are managed.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"team_id": {
+				Description: "By default, you can access resources contained within your own user account. To access resources owned by a team, you can pass in the team ID. Defaults to the team configured in the provider.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+			},
+			"record": {
+				Description:   "A record of the domain.",
+				Type:          schema.TypeSet,
+				Optional:      true,
+				ConflictsWith: []string{"zone_file"},
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"type": {
+							Description:  "The type of record, one of `A`, `AAAA`, `ALIAS`, `CAA`, `CNAME`, `HTTPS`, `MX`, `NS`, `SRV` or `TXT`.",
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringInSlice(dns.Types, false),
+						},
+						"name": {
+							Description: "A subdomain name or an empty string for the root domain.",
+							Type:        schema.TypeString,
+							Optional:    true,
+						},
+						"value": {
+							Description: "The record value. Required for all types except `SRV`, which uses the `srv` block instead.",
+							Type:        schema.TypeString,
+							Optional:    true,
+						},
+						"mx_priority": {
+							Description:  "The priority of an `MX` record, only allowed on `MX` records. Defaults to 0.",
+							Type:         schema.TypeInt,
+							Optional:     true,
+							ValidateFunc: validation.IntBetween(0, 65535),
+						},
+						"srv": {
+							Description: "The service an `SRV` record points to, required for and only allowed on `SRV` records.",
+							Type:        schema.TypeList,
+							Optional:    true,
+							MaxItems:    1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"priority": {
+										Description:  "The priority of the target.",
+										Type:         schema.TypeInt,
+										Required:     true,
+										ValidateFunc: validation.IntBetween(0, 65535),
+									},
+									"weight": {
+										Description:  "The relative weight of targets with the same priority.",
+										Type:         schema.TypeInt,
+										Required:     true,
+										ValidateFunc: validation.IntBetween(0, 65535),
+									},
+									"port": {
+										Description:  "The port the service is running on.",
+										Type:         schema.TypeInt,
+										Required:     true,
+										ValidateFunc: validation.IntBetween(0, 65535),
+									},
+									"target": {
+										Description: "The hostname of the machine providing the service.",
+										Type:        schema.TypeString,
+										Required:    true,
+									},
+								},
+							},
+						},
+						"comment": {
+							Description:  "A note about the record, at most 500 characters.",
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.StringLenBetween(0, dns.MaxCommentLength),
+						},
+						"ttl": {
+							Description: "The TTL value. Must be a number between 60 and 2147483647. Default value is 60.",
+							Type:        schema.TypeInt,
+							Optional:    true,
+							Default:     60,
+						},
+					},
+				},
+			},
+			"zone_file": {
+				Description:   "The records of the domain as a BIND zone file, e.g. `file(\"chronark.com.zone\")`. `SOA` records and the `NS` records of the root domain are ignored.",
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"record"},
+			},
+			"records": {
+				Description: "All records of the domain as they exist in vercel, including the records vercel creates automatically. The plan shows the records that remain after the apply, so records that are deleted disappear from it.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Description: "The unique identifier of the dns record.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"type": {
+							Description: "The type of record.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"name": {
+							Description: "A subdomain name or an empty string for the root domain.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"value": {
+							Description: "The record value. For `SRV` records this is `<weight> <port> <target>`.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"ttl": {
+							Description: "The TTL value of the record.",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+						"mx_priority": {
+							Description: "The priority of an `MX` record.",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+						"priority": {
+							Description: "The priority of an `SRV` record.",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+						"comment": {
+							Description: "A note about the record.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"creator": {
+							Description: "The ID of the user who created the record or system if the record is an automatic record.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// desiredZoneRecords returns the records configured either as `record` blocks or as `zone_file`.
+func desiredZoneRecords(d recordConfig) ([]dns.CreateRecord, error) {
+	domain := d.Get("domain").(string)
+
+	if zone := d.Get("zone_file").(string); zone != "" {
+		records, err := dns.ParseZone(zone, domain)
+		if err != nil {
+			return nil, fmt.Errorf("invalid zone file: %w", err)
+		}
+		return records, nil
+	}
+
+	records := []dns.CreateRecord{}
+	for _, raw := range d.Get("record").(*schema.Set).List() {
+		block := raw.(map[string]interface{})
+		record := dns.CreateRecord{
+			Type:    block["type"].(string),
+			Name:    block["name"].(string),
+			Value:   block["value"].(string),
+			TTL:     block["ttl"].(int),
+			Comment: block["comment"].(string),
+		}
+
+		// Unset integers are 0 inside of sets, so the priority is only used for MX records
+		priority := block["mx_priority"].(int)
+		if record.Type == dns.TypeMX {
+			record.MXPriority = &priority
+		} else if priority != 0 {
+			return nil, fmt.Errorf("invalid %s record %q: mx_priority is only allowed for MX records", record.Type, record.Name)
+		}
+
+		if srv := block["srv"].([]interface{}); len(srv) > 0 && srv[0] != nil {
+			srvBlock := srv[0].(map[string]interface{})
+			record.SRV = &dns.SRV{
+				Priority: srvBlock["priority"].(int),
+				Weight:   srvBlock["weight"].(int),
+				Port:     srvBlock["port"].(int),
+				Target:   srvBlock["target"].(string),
+			}
+		}
+
+		err := record.Validate()
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s record %q: %w", record.Type, record.Name, err)
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+// existingZoneRecords converts the `records` in the state back to records.
+func existingZoneRecords(d recordConfig) []dns.Record {
+	records := []dns.Record{}
+	for _, raw := range d.Get("records").([]interface{}) {
+		block := raw.(map[string]interface{})
+		records = append(records, dns.Record{
+			Id:         block["id"].(string),
+			Type:       block["type"].(string),
+			Name:       block["name"].(string),
+			Value:      block["value"].(string),
+			TTL:        block["ttl"].(int),
+			MXPriority: block["mx_priority"].(int),
+			Priority:   block["priority"].(int),
+			Comment:    block["comment"].(string),
+			Creator:    block["creator"].(string),
+		})
+	}
+	return records
+}
+
+func flattenZoneRecords(records []dns.Record) []interface{} {
+	flattened := make([]interface{}, len(records))
+	for i, record := range records {
+		flattened[i] = map[string]interface{}{
+			"id":          record.Id,
+			"type":        record.Type,
+			"name":        record.Name,
+			"value":       record.Value,
+			"ttl":         record.TTL,
+			"mx_priority": record.MXPriority,
+			"priority":    record.Priority,
+			"comment":     record.Comment,
+			"creator":     record.Creator,
+		}
+	}
+	return flattened
+}
+
+// resourceDNSZoneCustomizeDiff validates the configured records and plans the records that remain after the apply,
+// so the plan shows every record that is deleted, including records that were added outside of terraform.
+func resourceDNSZoneCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("record") || !d.NewValueKnown("zone_file") || !d.NewValueKnown("domain") {
+		return d.SetNewComputed("records")
+	}
+
+	desired, err := desiredZoneRecords(d)
+	if err != nil {
+		return err
+	}
+
+	existing := existingZoneRecords(d)
+	if d.Id() == "" {
+		domain := d.Get("domain").(string)
+		existing, err = meta.(*vercel.Client).DNS.List(ctx, domain, inheritTeamID(d.Get("team_id").(string), meta))
+		if err != nil {
+			// The domain is added in the same apply
+			if httpApi.IsNotFound(err) {
+				return d.SetNewComputed("records")
+			}
+			return err
+		}
+	}
+
+	changes := dns.Diff(desired, existing)
+	if d.Id() != "" && changes.Empty() {
+		return nil
+	}
+	for _, record := range changes.Delete {
+		log.Printf("[INFO] dns record %s %s %q of %s is not configured and will be deleted", record.Id, record.Type, record.Name, d.Get("domain").(string))
+	}
+	return d.SetNew("records", flattenZoneRecords(changes.Remaining(existing)))
+}
+
+// applyDNSZone compares the configured records with the records in vercel and makes the necessary changes.
+func applyDNSZone(ctx context.Context, d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*vercel.Client)
+	domain := d.Get("domain").(string)
+	teamId := d.Get("team_id").(string)
+
+	desired, err := desiredZoneRecords(d)
+	if err != nil {
+		return err
+	}
+
+	existing, err := client.DNS.List(ctx, domain, teamId)
+	if err != nil {
+		return err
+	}
+
+	return client.DNS.Apply(ctx, domain, dns.Diff(desired, existing), teamId)
+}
+
+func resourceDNSZoneCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	teamId := inheritTeamID(d.Get("team_id").(string), meta)
+	err := d.Set("team_id", teamId)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = applyDNSZone(ctx, d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(d.Get("domain").(string))
+
+	return resourceDNSZoneRead(ctx, d, meta)
+}
+
+func resourceDNSZoneRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*vercel.Client)
+
+	records, err := client.DNS.List(ctx, d.Get("domain").(string), d.Get("team_id").(string))
+	if err != nil {
+		if removedOutsideTerraform(d, err, "dns zone") {
+			return diag.Diagnostics{}
+		}
+		return diag.FromErr(err)
+	}
+
+	err = d.Set("records", flattenZoneRecords(records))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diag.Diagnostics{}
+}
+
+func resourceDNSZoneUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	err := applyDNSZone(ctx, d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceDNSZoneRead(ctx, d, meta)
+}
+
+// resourceDNSZoneDelete removes all records of the domain that were not created by vercel. This includes records
+// that were never managed by terraform, e.g. records that were added by hand in the dashboard.
+func resourceDNSZoneDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*vercel.Client)
+	domain := d.Get("domain").(string)
+	teamId := d.Get("team_id").(string)
+
+	existing, err := client.DNS.List(ctx, domain, teamId)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = client.DNS.Apply(ctx, domain, dns.Diff(nil, existing), teamId)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId("")
+	return diag.Diagnostics{}
+}
+
+func resourceDNSZoneImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	teamID, parts, err := splitImportID(d.Id(), 1, "`[team_id/]domain`")
+	if err != nil {
+		return nil, err
+	}
+
+	err = d.Set("team_id", inheritTeamID(teamID, meta))
+	if err != nil {
+		return nil, err
+	}
+	err = d.Set("domain", parts[0])
+	if err != nil {
+		return nil, err
+	}
+	d.SetId(parts[0])
+
+	return []*schema.ResourceData{d}, nil
+}
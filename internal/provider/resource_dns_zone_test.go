@@ -0,0 +1,83 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/chronark/terraform-provider-vercel/pkg/vercel/dns"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccVercelDNSZone(t *testing.T) {
+	domain := "acceptance-test-dns-zone.com"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckVercelDNSZoneConfig(domain, "1.1.1.1"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vercel_dns_zone.new", "records.#", "2"),
+				),
+			},
+			{
+				// A record added outside of terraform is deleted again
+				PreConfig: func() {
+					_, err := testAccClient().DNS.Create(context.Background(), domain, dns.CreateRecord{Type: dns.TypeA, Name: "manual", Value: "8.8.8.8", TTL: 60}, "")
+					if err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config: testAccCheckVercelDNSZoneConfig(domain, "1.1.1.1"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vercel_dns_zone.new", "records.#", "2"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(`
+				resource "vercel_domain" "new" {
+					name = "%s"
+				}
+
+				resource "vercel_dns_zone" "new" {
+					domain    = vercel_domain.new.name
+					zone_file = <<-ZONE
+						$TTL 300
+						@    A     8.8.8.8
+						mail MX 10 mx1.improvmx.com.
+					ZONE
+				}
+				`, domain),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vercel_dns_zone.new", "records.#", "2"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckVercelDNSZoneConfig(domain string, value string) string {
+	return fmt.Sprintf(`
+	resource "vercel_domain" "new" {
+		name = "%s"
+	}
+
+	resource "vercel_dns_zone" "new" {
+		domain = vercel_domain.new.name
+
+		record {
+			type  = "A"
+			name  = ""
+			value = "%s"
+		}
+
+		record {
+			type  = "CNAME"
+			name  = "www"
+			value = "cname.vercel-dns.com"
+		}
+	}
+	`, domain, value)
+}
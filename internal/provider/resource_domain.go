@@ -2,10 +2,17 @@ package provider
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
 
 	"github.com/chronark/terraform-provider-vercel/pkg/vercel"
+	"github.com/chronark/terraform-provider-vercel/pkg/vercel/domain"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceDomain() *schema.Resource {
@@ -14,15 +21,25 @@ func resourceDomain() *schema.Resource {
 
 		CreateContext: resourceDomainCreate,
 		ReadContext:   resourceDomainRead,
+		UpdateContext: resourceDomainUpdate,
 		DeleteContext: resourceDomainDelete,
+		CustomizeDiff: resourceDomainCustomizeDiff,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceDomainImport,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+			Update: schema.DefaultTimeout(30 * time.Minute),
+		},
 
 		Schema: map[string]*schema.Schema{
 			"team_id": {
-				Description: "By default, you can access resources contained within your own user account. To access resources owned by a team, you can pass in the team ID",
+				Description: "By default, you can access resources contained within your own user account. To access resources owned by a team, you can pass in the team ID. Defaults to the team configured in the provider.",
 				Type:        schema.TypeString,
 				Optional:    true,
+				Computed:    true,
 				ForceNew:    true,
-				Default:     "",
 			},
 			"name": {
 				Description: "The name of the production domain.",
@@ -30,6 +47,42 @@ func resourceDomain() *schema.Resource {
 				ForceNew:    true,
 				Required:    true,
 			},
+			"method": {
+				Description:  "How the domain is added, either `add` for a domain registered elsewhere, `buy` to register it through vercel or `transfer-in` to move it to vercel. Cannot be changed after the domain was added.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      domain.MethodAdd,
+				ValidateFunc: validation.StringInSlice(domain.Methods, false),
+			},
+			"expected_price": {
+				Description:  "The price in US dollars the purchase or transfer is expected to cost. Required for `buy` and `transfer-in`, nothing is charged if the price differs. Cannot be changed after the domain was added.",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"auth_code": {
+				Description: "The authorization code of the current registrar. Required for `transfer-in`. Cannot be changed after the domain was added.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+			},
+			"auto_renew": {
+				Description: "Whether a domain registered through vercel is renewed automatically before it expires. Vercel turns this on for bought and transferred domains, it cannot be set for domains added with `add`.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+			},
+			"registrar": {
+				Description: "The registrar of the domain.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"wait_for_verification": {
+				Description: "Whether to wait until the domain is verified, either through its nameservers or a TXT record. A domain that is not verified within the create timeout is tainted.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
 			"id": {
 				Description: "Unique id for this variable.",
 				Type:        schema.TypeString,
@@ -126,24 +179,169 @@ func resourceDomainCreate(ctx context.Context, d *schema.ResourceData, meta inte
 
 	client := meta.(*vercel.Client)
 
-	id, err := client.Domain.Create(d.Get("name").(string), d.Get("team_id").(string))
+	teamId := inheritTeamID(d.Get("team_id").(string), meta)
+	err := d.Set("team_id", teamId)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	name := d.Get("name").(string)
+	autoRenew, autoRenewSet := d.GetOkExists("auto_renew")
+
+	var id string
+	switch d.Get("method").(string) {
+	case domain.MethodBuy:
+		buy := domain.BuyDomain{Name: name, ExpectedPrice: d.Get("expected_price").(int), Renew: true}
+		if autoRenewSet {
+			buy.Renew = autoRenew.(bool)
+		}
+		id, err = client.Domain.Buy(ctx, buy, teamId)
+	case domain.MethodTransferIn:
+		id, err = client.Domain.TransferIn(ctx, name, d.Get("auth_code").(string), d.Get("expected_price").(int), teamId)
+	default:
+		id, err = client.Domain.Create(ctx, name, teamId)
+	}
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
 	d.SetId(id)
 
+	// Transferred domains are renewed automatically unless turned off
+	if d.Get("method").(string) == domain.MethodTransferIn && autoRenewSet && !autoRenew.(bool) {
+		err = client.Domain.SetRenew(ctx, name, false, teamId)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	if d.Get("wait_for_verification").(bool) {
+		err = waitForDomainVerification(ctx, client, d.Get("name").(string), teamId, d.Timeout(schema.TimeoutCreate))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceDomainRead(ctx, d, meta)
+}
+
+// resourceDomainCustomizeDiff checks the price before a domain is bought or transferred, so a wrong
+// expected_price fails the plan instead of the apply. The settings that only matter when the domain is
+// added cannot change afterwards, unless they are not known yet after an import.
+func resourceDomainCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() != "" {
+		for _, key := range []string{"method", "expected_price", "auth_code"} {
+			if old, _ := d.GetChange(key); d.HasChange(key) && old != "" && old != 0 {
+				return fmt.Errorf("%s cannot be changed after the domain was added", key)
+			}
+		}
+		if d.HasChange("auto_renew") && d.Get("service_type").(string) != domain.ServiceTypeRegistered {
+			return fmt.Errorf("auto_renew can only be set for domains registered through vercel")
+		}
+		return nil
+	}
+
+	method := d.Get("method").(string)
+	if method == domain.MethodAdd {
+		if d.NewValueKnown("auto_renew") {
+			return fmt.Errorf("auto_renew can only be set for domains that are bought or transferred in")
+		}
+		return nil
+	}
+	if method == domain.MethodTransferIn && d.NewValueKnown("auth_code") && d.Get("auth_code").(string) == "" {
+		return fmt.Errorf("auth_code is required to transfer a domain")
+	}
+	if !d.NewValueKnown("name") || !d.NewValueKnown("expected_price") {
+		return nil
+	}
+
+	priceType := domain.PriceTypeNew
+	if method == domain.MethodTransferIn {
+		priceType = domain.PriceTypeTransfer
+	}
+	name := d.Get("name").(string)
+	price, err := meta.(*vercel.Client).Domain.Price(ctx, name, priceType, inheritTeamID(d.Get("team_id").(string), meta))
+	if err != nil {
+		return err
+	}
+
+	expectedPrice := d.Get("expected_price").(int)
+	if expectedPrice == 0 {
+		return fmt.Errorf("%s costs $%d for %d year(s), set expected_price = %d to confirm the price", name, price.Price, price.Period, price.Price)
+	}
+	if expectedPrice != price.Price {
+		return fmt.Errorf("%s costs $%d for %d year(s) but expected_price is %d", name, price.Price, price.Period, expectedPrice)
+	}
+	return nil
+}
+
+// waitForDomainVerification asks vercel to verify the domain until it succeeds. If the domain is still not
+// verified after the timeout, the error names the nameservers vercel expects and the ones the domain uses.
+func waitForDomainVerification(ctx context.Context, client *vercel.Client, name string, teamId string, timeout time.Duration) error {
+	var last domain.Domain
+	err := waitForVerification(ctx, "domain", name, timeout, func() error {
+		d, err := client.Domain.Read(ctx, name, teamId)
+		if err != nil || d.Verified {
+			return err
+		}
+		last = d
+		_, err = client.Domain.Verify(ctx, name, teamId)
+		return err
+	})
+
+	var timeoutErr *resource.TimeoutError
+	if errors.As(err, &timeoutErr) && last.Name != "" {
+		return fmt.Errorf("domain %s is not verified, vercel expects the nameservers %s but the domain uses %s: %w",
+			name, listOrNone(last.IntendedNameservers), listOrNone(last.Nameservers), err)
+	}
+	return err
+}
+
+func listOrNone(values []string) string {
+	if len(values) == 0 {
+		return "none"
+	}
+	return strings.Join(values, ", ")
+}
+
+// Only auto_renew and wait_for_verification change in place, turning on wait_for_verification waits for an unverified domain.
+func resourceDomainUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*vercel.Client)
+
+	if d.HasChange("auto_renew") {
+		err := client.Domain.SetRenew(ctx, d.Get("name").(string), d.Get("auto_renew").(bool), d.Get("team_id").(string))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	if d.HasChange("wait_for_verification") && d.Get("wait_for_verification").(bool) {
+		err := waitForDomainVerification(ctx, client, d.Get("name").(string), d.Get("team_id").(string), d.Timeout(schema.TimeoutUpdate))
+		if err != nil {
+			// Keep waiting turned off, so the next apply tries again
+			d.Partial(true)
+			return diag.FromErr(err)
+		}
+	}
+
 	return resourceDomainRead(ctx, d, meta)
 }
 
 func resourceDomainRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*vercel.Client)
 
-	domain, err := client.Domain.Read(d.Get("name").(string), d.Get("team_id").(string))
+	domain, err := client.Domain.Read(ctx, d.Get("name").(string), d.Get("team_id").(string))
 	if err != nil {
+		if removedOutsideTerraform(d, err, "domain") {
+			return diag.Diagnostics{}
+		}
 		return diag.FromErr(err)
 	}
 	d.SetId(domain.ID)
+	err = d.Set("name", domain.Name)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	err = d.Set("service_type", domain.ServiceType)
 	if err != nil {
 		return diag.FromErr(err)
@@ -173,7 +371,7 @@ func resourceDomainRead(ctx context.Context, d *schema.ResourceData, meta interf
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	err = d.Set("transfer_started_at", domain.TransferredAt)
+	err = d.Set("transfer_started_at", domain.TransferStartedAt)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -181,6 +379,14 @@ func resourceDomainRead(ctx context.Context, d *schema.ResourceData, meta interf
 	if err != nil {
 		return diag.FromErr(err)
 	}
+	err = d.Set("auto_renew", domain.Renew)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	err = d.Set("registrar", domain.Registrar)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	err = d.Set("verification_record", domain.VerificationRecord)
 	if err != nil {
 		return diag.FromErr(err)
@@ -206,10 +412,32 @@ func resourceDomainDelete(ctx context.Context, d *schema.ResourceData, meta inte
 
 	domainName := d.Get("name").(string)
 
-	err := client.Domain.Delete(domainName, d.Get("team_id").(string))
+	err := client.Domain.Delete(ctx, domainName, d.Get("team_id").(string))
 	if err != nil {
 		return diag.FromErr(err)
 	}
 	d.SetId("")
 	return diag.Diagnostics{}
 }
+
+func resourceDomainImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	teamID, parts, err := splitImportID(d.Id(), 1, "`[team_id/]name`")
+	if err != nil {
+		return nil, err
+	}
+
+	err = d.Set("team_id", inheritTeamID(teamID, meta))
+	if err != nil {
+		return nil, err
+	}
+	err = d.Set("name", parts[0])
+	if err != nil {
+		return nil, err
+	}
+	err = d.Set("wait_for_verification", false)
+	if err != nil {
+		return nil, err
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
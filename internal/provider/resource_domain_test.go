@@ -1,11 +1,11 @@
 package provider
 
 import (
+	"context"
 	"fmt"
-	"os"
+	"regexp"
 	"testing"
 
-	"github.com/chronark/terraform-provider-vercel/pkg/vercel"
 	"github.com/chronark/terraform-provider-vercel/pkg/vercel/domain"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
@@ -38,6 +38,12 @@ func TestAccVercelDomain(t *testing.T) {
 					testAccCheckActualDomainHasValues(&actualDomainAfterCreation, &domain.Domain{Name: domainName}),
 				),
 			},
+			{
+				ResourceName:      "vercel_domain.new",
+				ImportState:       true,
+				ImportStateId:     domainName,
+				ImportStateVerify: true,
+			},
 			{
 				Config: testAccCheckVercelDomainConfig(updatedDomainName),
 				Check: resource.ComposeTestCheckFunc(
@@ -53,6 +59,75 @@ func TestAccVercelDomain(t *testing.T) {
 	})
 }
 
+func TestAccVercelDomainWaitForVerification(t *testing.T) {
+	domainName := "acceptancetestdomainwait.com"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testAccCheckVercelDomainDestroy(domainName),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+				resource "vercel_domain" "new" {
+					name                  = "%s"
+					wait_for_verification = true
+					timeouts {
+						create = "1s"
+					}
+				}
+				`, domainName),
+				ExpectError: regexp.MustCompile(`vercel expects the nameservers ns1.vercel-dns.com, ns2.vercel-dns.com but the domain uses none`),
+			},
+		},
+	})
+}
+
+func TestAccVercelDomainBuy(t *testing.T) {
+	testAccFakeOnly(t)
+	domainName := "acceptancetestdomainbuy.com"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testAccCheckVercelDomainDestroy(domainName),
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccCheckVercelDomainBuyConfig(domainName, 10, true),
+				ExpectError: regexp.MustCompile(`costs \$20 for 1 year\(s\) but expected_price is 10`),
+			},
+			{
+				Config: testAccCheckVercelDomainBuyConfig(domainName, 20, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vercel_domain.new", "verified", "true"),
+					resource.TestCheckResourceAttr("vercel_domain.new", "auto_renew", "true"),
+					resource.TestCheckResourceAttr("vercel_domain.new", "registrar", "Vercel"),
+					resource.TestCheckResourceAttr("vercel_domain.new", "service_type", domain.ServiceTypeRegistered),
+				),
+			},
+			{
+				Config: testAccCheckVercelDomainBuyConfig(domainName, 20, false),
+				Check:  resource.TestCheckResourceAttr("vercel_domain.new", "auto_renew", "false"),
+			},
+			{
+				Config:      testAccCheckVercelDomainBuyConfig(domainName, 25, false),
+				ExpectError: regexp.MustCompile(`expected_price cannot be changed after the domain was added`),
+			},
+		},
+	})
+}
+
+func testAccCheckVercelDomainBuyConfig(name string, expectedPrice int, autoRenew bool) string {
+	return fmt.Sprintf(`
+	resource "vercel_domain" "new" {
+		name           = "%s"
+		method         = "buy"
+		expected_price = %d
+		auto_renew     = %t
+	}
+	`, name, expectedPrice, autoRenew)
+}
+
 // Combines multiple `resource.TestCheckResourceAttr` calls
 func testAccCheckDomainStateHasValues(name string, want domain.Domain) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
@@ -97,27 +172,19 @@ func testAccCheckActualDomainHasValues(actual *domain.Domain, want *domain.Domai
 // Test whether the domain was destroyed properly and finishes the job if necessary
 func testAccCheckVercelDomainDestroy(name string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
-		client := vercel.New(os.Getenv("VERCEL_TOKEN"))
-
-		for _, rs := range s.RootModule().Resources {
-			if rs.Type != name {
-				continue
+		client := testAccClient()
+
+		domain, err := client.Domain.Read(context.Background(), name, "")
+		if err == nil {
+			message := "Domain was not deleted from vercel during terraform destroy."
+			deleteErr := client.Domain.Delete(context.Background(), domain.Name, "")
+			if deleteErr != nil {
+				return fmt.Errorf(message+" Automated removal did not succeed. Please manually remove @%s. Error: %w", domain.Name, deleteErr)
 			}
-
-			domain, err := client.Domain.Read(rs.Primary.ID, "")
-			if err == nil {
-				message := "Domain was not deleted from vercel during terraform destroy."
-				deleteErr := client.Domain.Delete(domain.Name, "")
-				if deleteErr != nil {
-					return fmt.Errorf(message+" Automated removal did not succeed. Please manually remove @%s. Error: %w", domain.Name, err)
-				}
-				return fmt.Errorf(message + " It was removed now.")
-			}
-
+			return fmt.Errorf(message + " It was removed now.")
 		}
 		return nil
 	}
-
 }
 func testAccCheckVercelDomainConfig(name string) string {
 	return fmt.Sprintf(`
@@ -139,7 +206,7 @@ func testAccCheckVercelDomainExists(n string, actual *domain.Domain) resource.Te
 			return fmt.Errorf("No domain set")
 		}
 
-		domain, err := vercel.New(os.Getenv("VERCEL_TOKEN")).Domain.Read(rs.Primary.Attributes["name"], "")
+		domain, err := testAccClient().Domain.Read(context.Background(), rs.Primary.Attributes["name"], "")
 		if err != nil {
 			return err
 		}
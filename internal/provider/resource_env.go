@@ -2,11 +2,13 @@ package provider
 
 import (
 	"context"
+	"log"
 
 	"github.com/chronark/terraform-provider-vercel/pkg/vercel"
 	"github.com/chronark/terraform-provider-vercel/pkg/vercel/env"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceEnv() *schema.Resource {
@@ -17,6 +19,9 @@ func resourceEnv() *schema.Resource {
 		ReadContext:   resourceEnvRead,
 		UpdateContext: resourceEnvUpdate,
 		DeleteContext: resourceEnvDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceEnvImport,
+		},
 
 		Schema: map[string]*schema.Schema{
 			"project_id": {
@@ -25,16 +30,17 @@ func resourceEnv() *schema.Resource {
 				Required:    true,
 			},
 			"team_id": {
-				Description: "By default, you can access resources contained within your own user account. To access resources owned by a team, you can pass in the team ID",
+				Description: "By default, you can access resources contained within your own user account. To access resources owned by a team, you can pass in the team ID. Defaults to the team configured in the provider.",
 				Type:        schema.TypeString,
 				Optional:    true,
+				Computed:    true,
 				ForceNew:    true,
-				Default:     "",
 			},
 			"type": {
-				Description: "The type can be `plain`, `secret`, or `system`.",
-				Type:        schema.TypeString,
-				Required:    true,
+				Description:  "The type can be `plain`, `secret`, `system`, `encrypted` or `sensitive`. Vercel never returns the value of `sensitive` variables, changes to them outside of terraform are detected through `updated_at` instead.",
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice(env.Types, false),
 			},
 			"id": {
 				Description: "Unique id for this variable.",
@@ -47,9 +53,10 @@ func resourceEnv() *schema.Resource {
 				Required:    true,
 			},
 			"value": {
-				Description: "If the type is `plain`, a string representing the value of the environment variable. If the type is `secret`, the secret ID of the secret attached to the environment variable. If the type is `system`, the name of the System Environment Variable.",
+				Description: "If the type is `plain`, `encrypted` or `sensitive`, a string representing the value of the environment variable. If the type is `secret`, the secret ID of the secret attached to the environment variable. If the type is `system`, the name of the System Environment Variable.",
 				Type:        schema.TypeString,
 				Required:    true,
+				Sensitive:   true,
 			},
 			"target": {
 				Description: "The target can be a list of `development`, `preview`, or `production`.",
@@ -107,32 +114,37 @@ func resourceEnvCreate(ctx context.Context, d *schema.ResourceData, meta interfa
 
 	payload := toCreateOrUpdateEnv(d)
 
-	envID, err := client.Env.Create(d.Get("project_id").(string), payload, d.Get("team_id").(string))
+	teamId := inheritTeamID(d.Get("team_id").(string), meta)
+	err := d.Set("team_id", teamId)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	envID, err := client.Env.Create(ctx, d.Get("project_id").(string), payload, teamId)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
 	d.SetId(envID)
 
-	return resourceEnvRead(ctx, d, meta)
+	return readEnv(ctx, d, meta, true)
 }
 
 func resourceEnvRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return readEnv(ctx, d, meta, false)
+}
+
+// readEnv refreshes the state of a variable. written is true right after terraform changed the variable,
+// otherwise a newer `updatedAt` of a variable with a hidden value means it was changed outside of terraform.
+func readEnv(ctx context.Context, d *schema.ResourceData, meta interface{}, written bool) diag.Diagnostics {
 	client := meta.(*vercel.Client)
 
-	id := d.Id()
-	allEnvVariables, err := client.Env.Read(d.Get("project_id").(string), d.Get("team_id").(string))
+	currentVar, err := client.Env.Get(ctx, d.Get("project_id").(string), d.Id(), true, d.Get("team_id").(string))
 	if err != nil {
-		return diag.FromErr(err)
-	}
-
-	// Filter the current variable out of all existing ones
-	var currentVar env.Env
-	for _, envVar := range allEnvVariables {
-		if envVar.ID == id {
-			currentVar = envVar
-			break
+		if removedOutsideTerraform(d, err, "environment variable") {
+			return diag.Diagnostics{}
 		}
+		return diag.FromErr(err)
 	}
 
 	err = d.Set("type", currentVar.Type)
@@ -143,9 +155,18 @@ func resourceEnvRead(ctx context.Context, d *schema.ResourceData, meta interface
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	err = d.Set("value", currentVar.Value)
-	if err != nil {
-		return diag.FromErr(err)
+	if currentVar.HasValue() {
+		err = d.Set("value", currentVar.Value)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	} else if !written && int64(d.Get("updated_at").(int)) != currentVar.UpdatedAt {
+		// The value in the state is the last one terraform wrote. Forgetting it makes the next plan write it again.
+		log.Printf("[WARN] environment variable %s was changed outside of terraform", d.Id())
+		err = d.Set("value", "")
+		if err != nil {
+			return diag.FromErr(err)
+		}
 	}
 	err = d.Set("target", currentVar.Target)
 	if err != nil {
@@ -178,10 +199,11 @@ func resourceEnvUpdate(ctx context.Context, d *schema.ResourceData, meta interfa
 		envID := d.Id()
 		payload := toCreateOrUpdateEnv(d)
 
-		err := client.Env.Update(projectID, envID, payload, d.Get("team_id").(string))
+		err := client.Env.Update(ctx, projectID, envID, payload, d.Get("team_id").(string))
 		if err != nil {
 			return diag.FromErr(err)
 		}
+		return readEnv(ctx, d, meta, true)
 	}
 
 	return resourceEnvRead(ctx, d, meta)
@@ -194,7 +216,7 @@ func resourceEnvDelete(ctx context.Context, d *schema.ResourceData, meta interfa
 	projectID := d.Get("project_id").(string)
 	envID := d.Get("id").(string)
 
-	err := client.Env.Delete(projectID, envID, d.Get("team_id").(string))
+	err := client.Env.Delete(ctx, projectID, envID, d.Get("team_id").(string))
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -203,3 +225,22 @@ func resourceEnvDelete(ctx context.Context, d *schema.ResourceData, meta interfa
 
 	return diag.Diagnostics{}
 }
+
+func resourceEnvImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	teamID, parts, err := splitImportID(d.Id(), 2, "`[team_id/]project_id/env_id`")
+	if err != nil {
+		return nil, err
+	}
+
+	err = d.Set("team_id", inheritTeamID(teamID, meta))
+	if err != nil {
+		return nil, err
+	}
+	err = d.Set("project_id", parts[0])
+	if err != nil {
+		return nil, err
+	}
+	d.SetId(parts[1])
+
+	return []*schema.ResourceData{d}, nil
+}
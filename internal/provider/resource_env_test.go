@@ -0,0 +1,112 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/chronark/terraform-provider-vercel/pkg/vercel/env"
+	"github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccVercelEnvSensitive(t *testing.T) {
+	projectName, _ := uuid.GenerateUUID()
+	var envID, projectID string
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testAccCheckVercelProjectDestroy(projectName),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckVercelEnvConfig(projectName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vercel_env.token", "value", "hunter2"),
+					func(s *terraform.State) error {
+						envID = s.RootModule().Resources["vercel_env.token"].Primary.ID
+						projectID = s.RootModule().Resources["vercel_project.new"].Primary.ID
+						return nil
+					},
+				),
+			},
+			{
+				// Changing the value outside of terraform is detected through `updatedAt` and reverted
+				PreConfig: func() {
+					err := testAccClient().Env.Update(context.Background(), projectID, envID, env.CreateOrUpdateEnv{
+						Type:   env.TypeSensitive,
+						Key:    "TOKEN",
+						Value:  "changed",
+						Target: []string{"production"},
+					}, "")
+					if err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config:             testAccCheckVercelEnvConfig(projectName),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: testAccCheckVercelEnvConfig(projectName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vercel_env.token", "value", "hunter2"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckVercelEnvConfig(projectName string) string {
+	return fmt.Sprintf(`
+	resource "vercel_project" "new" {
+		name = "%s"
+		git_repository {
+			type = "github"
+			repo = "chronark/terraform-provider-vercel"
+		}
+	}
+
+	resource "vercel_env" "token" {
+		project_id = vercel_project.new.id
+		type       = "sensitive"
+		key        = "TOKEN"
+		value      = "hunter2"
+		target     = ["production"]
+	}
+	`, projectName)
+}
+
+func TestAccVercelEnvDeletedOutsideTerraform(t *testing.T) {
+	projectName, _ := uuid.GenerateUUID()
+	var envID, projectID string
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testAccCheckVercelProjectDestroy(projectName),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckVercelEnvConfig(projectName),
+				Check: func(s *terraform.State) error {
+					envID = s.RootModule().Resources["vercel_env.token"].Primary.ID
+					projectID = s.RootModule().Resources["vercel_project.new"].Primary.ID
+					return nil
+				},
+			},
+			{
+				// The variable is dropped from the state and planned to be created again
+				PreConfig: func() {
+					err := testAccClient().Env.Delete(context.Background(), projectID, envID, "")
+					if err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config:             testAccCheckVercelEnvConfig(projectName),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
@@ -2,11 +2,13 @@ package provider
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/chronark/terraform-provider-vercel/pkg/vercel"
 	"github.com/chronark/terraform-provider-vercel/pkg/vercel/project"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceProject() *schema.Resource {
@@ -17,6 +19,9 @@ func resourceProject() *schema.Resource {
 		ReadContext:   resourceProjectRead,
 		UpdateContext: resourceProjectUpdate,
 		DeleteContext: resourceProjectDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceProjectImport,
+		},
 
 		Schema: map[string]*schema.Schema{
 			"id": {
@@ -30,16 +35,15 @@ func resourceProject() *schema.Resource {
 				Required:    true,
 			},
 			"team_id": {
-				Description: "By default, you can access resources contained within your own user account. To access resources owned by a team, you can pass in the team ID",
+				Description: "By default, you can access resources contained within your own user account. To access resources owned by a team, you can pass in the team ID. Defaults to the team configured in the provider.",
 				Type:        schema.TypeString,
 				Optional:    true,
+				Computed:    true,
 				ForceNew:    true,
-				Default:     "",
 			},
 			"git_repository": {
-				Description: "The git repository that will be connected to the project. Any pushes to the specified connected git repository will be automatically deployed.",
-				Required:    true,
-				ForceNew:    true,
+				Description: "The git repository that will be connected to the project. Any pushes to the specified connected git repository will be automatically deployed. Changing the repository or removing it connects or disconnects the project without recreating it.",
+				Optional:    true,
 				Type:        schema.TypeList,
 				MaxItems:    1,
 				Elem: &schema.Resource{
@@ -57,6 +61,23 @@ func resourceProject() *schema.Resource {
 					},
 				},
 			},
+			"production_branch": {
+				Description:  "The branch of the git repository that is deployed to production. Defaults to the default branch of the repository, vercel picks the default branch again when the repository changes and no branch is configured.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				RequiredWith: []string{"git_repository"},
+			},
+			"command_for_ignoring_build_step": {
+				Description: "A command that decides whether a commit is built. When it exits with code 0 the build is skipped.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"git_fork_protection": {
+				Description: "Whether pull requests from forks of the repository require an authorization by a team member before they are deployed.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+			},
 			"account_id": {
 				Description: "The unique ID of the user or team the project belongs to.",
 				Type:        schema.TypeString,
@@ -105,7 +126,7 @@ func resourceProject() *schema.Resource {
 				Default:     nil,
 			},
 			"serverless_function_region": {
-				Description: "The region to deploy Serverless Functions in this project.",
+				Description: "The region to deploy Serverless Functions in this project. Removing it from the configuration keeps the current region.",
 				Type:        schema.TypeString,
 				Optional:    true,
 				Computed:    true,
@@ -117,11 +138,58 @@ func resourceProject() *schema.Resource {
 				Default:     nil,
 			},
 			"node_version": {
-				Description: "The Node.js Version for this project.",
+				Description: "The Node.js Version for this project. Removing it from the configuration keeps the current version.",
 				Type:        schema.TypeString,
 				Optional:    true,
 				Computed:    true,
 			},
+			"function_default_memory_type": {
+				Description:  "The memory and cpu of the serverless functions of this project, either `standard_legacy`, `standard` or `performance`.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringInSlice(project.MemoryTypes, false),
+			},
+			"function_default_timeout": {
+				Description:  "The maximum duration of the serverless functions of this project in seconds.",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntBetween(1, 900),
+			},
+			"function_default_regions": {
+				Description: "The regions the serverless functions of this project are deployed to.",
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"directory_listing": {
+				Description: "Whether a listing of the files is shown for paths that point to a directory without an index file. Removing it from the configuration keeps the current setting.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+			},
+			"auto_expose_system_envs": {
+				Description: "Whether the system environment variables are exposed to builds and functions automatically. Removing it from the configuration keeps the current setting.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+			},
+			"source_files_outside_root_directory": {
+				Description: "Whether files outside of the root directory are available to builds. Removing it from the configuration keeps the current setting.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+			},
+			"skew_protection_max_age": {
+				Description:  "How long previous deployments keep serving clients that were loaded from them in seconds. Skew protection is disabled when this is not set.",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
 			"alias": {
 				Description: "A list of production domains for the project.",
 				Type:        schema.TypeList,
@@ -138,60 +206,85 @@ func resourceProject() *schema.Resource {
 func resourceProjectCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 
 	client := meta.(*vercel.Client)
-	// Terraform does not have nested objects with different types yet, so I am using a `TypeList`
-	// Here we have to typecast to list first and then take the first item and cast again.
-	repo := d.Get("git_repository").([]interface{})[0].(map[string]interface{})
-	project := project.CreateProject{
-		Name: d.Get("name").(string),
-		GitRepository: struct {
-			Type string `json:"type"`
-			Repo string `json:"repo"`
-		}{
-			Type: repo["type"].(string),
-			Repo: repo["repo"].(string),
-		},
+	create := project.CreateProject{
+		Name:          d.Get("name").(string),
+		GitRepository: gitRepository(d),
 	}
 
 	framework, frameworkSet := d.GetOk("framework")
 	if frameworkSet {
-		project.Framework = framework.(string)
+		create.Framework = project.StringOrNull(framework.(string))
 	}
 	publicSource, publicSourceSet := d.GetOk("public_source")
 	if publicSourceSet {
-		project.PublicSource = publicSource.(bool)
+		value := publicSource.(bool)
+		create.PublicSource = &value
 	}
 	installCommand, installCommandSet := d.GetOk("install_command")
 	if installCommandSet {
-		project.InstallCommand = installCommand.(string)
+		create.InstallCommand = project.StringOrNull(installCommand.(string))
 	}
 	buildCommand, buildCommandSet := d.GetOk("build_command")
 	if buildCommandSet {
-		project.BuildCommand = buildCommand.(string)
+		create.BuildCommand = project.StringOrNull(buildCommand.(string))
 	}
 	devCommand, devCommandSet := d.GetOk("dev_command")
 	if devCommandSet {
-		project.DevCommand = devCommand.(string)
+		create.DevCommand = project.StringOrNull(devCommand.(string))
 	}
 	outputDirectory, outputDirectorySet := d.GetOk("output_directory")
 	if outputDirectorySet {
-		project.OutputDirectory = outputDirectory.(string)
+		create.OutputDirectory = project.StringOrNull(outputDirectory.(string))
 	}
 
 	serverlessFunctionRegion, serverlessFunctionRegionSet := d.GetOk("serverless_function_region")
 	if serverlessFunctionRegionSet {
-		project.ServerlessFunctionRegion = serverlessFunctionRegion.(string)
+		create.ServerlessFunctionRegion = serverlessFunctionRegion.(string)
 	}
 	rootDirectory, rootDirectorySet := d.GetOk("root_directory")
 	if rootDirectorySet {
-		project.RootDirectory = rootDirectory.(string)
+		create.RootDirectory = project.StringOrNull(rootDirectory.(string))
 	}
 	nodeVersion, nodeVersionSet := d.GetOk("node_version")
 	if nodeVersionSet {
-		project.NodeVersion = nodeVersion.(string)
+		create.NodeVersion = nodeVersion.(string)
 
 	}
+	commandForIgnoringBuildStep, commandForIgnoringBuildStepSet := d.GetOk("command_for_ignoring_build_step")
+	if commandForIgnoringBuildStepSet {
+		create.CommandForIgnoringBuildStep = project.StringOrNull(commandForIgnoringBuildStep.(string))
+	}
+	gitForkProtection := d.Get("git_fork_protection").(bool)
+	create.GitForkProtection = &gitForkProtection
+	directoryListing, directoryListingSet := d.GetOkExists("directory_listing")
+	if directoryListingSet {
+		value := directoryListing.(bool)
+		create.DirectoryListing = &value
+	}
+	autoExposeSystemEnvs, autoExposeSystemEnvsSet := d.GetOkExists("auto_expose_system_envs")
+	if autoExposeSystemEnvsSet {
+		value := autoExposeSystemEnvs.(bool)
+		create.AutoExposeSystemEnvs = &value
+	}
+	sourceFilesOutsideRootDirectory, sourceFilesOutsideRootDirectorySet := d.GetOkExists("source_files_outside_root_directory")
+	if sourceFilesOutsideRootDirectorySet {
+		value := sourceFilesOutsideRootDirectory.(bool)
+		create.SourceFilesOutsideRootDirectory = &value
+	}
+	skewProtectionMaxAge, skewProtectionMaxAgeSet := d.GetOk("skew_protection_max_age")
+	if skewProtectionMaxAgeSet {
+		value := skewProtectionMaxAge.(int)
+		create.SkewProtectionMaxAge = &value
+	}
+	create.ResourceConfig = resourceConfig(d)
 
-	id, err := client.Project.Create(project, d.Get("team_id").(string))
+	teamId := inheritTeamID(d.Get("team_id").(string), meta)
+	err := d.Set("team_id", teamId)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	id, err := client.Project.Create(ctx, create, teamId)
 
 	if err != nil {
 		return diag.FromErr(err)
@@ -199,6 +292,15 @@ func resourceProjectCreate(ctx context.Context, d *schema.ResourceData, meta int
 
 	d.SetId(id)
 
+	// The production branch can only be changed once the repository is connected
+	productionBranch, productionBranchSet := d.GetOk("production_branch")
+	if productionBranchSet {
+		err = client.Project.Update(ctx, id, project.UpdateProject{Branch: productionBranch.(string)}, teamId)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	return resourceProjectRead(ctx, d, meta)
 }
 
@@ -207,8 +309,11 @@ func resourceProjectRead(ctx context.Context, d *schema.ResourceData, meta inter
 
 	id := d.Id()
 
-	project, err := client.Project.Read(id, d.Get("team_id").(string))
+	project, err := client.Project.Read(ctx, id, d.Get("team_id").(string))
 	if err != nil {
+		if removedOutsideTerraform(d, err, "project") {
+			return diag.Diagnostics{}
+		}
 		return diag.FromErr(err)
 	}
 
@@ -267,6 +372,63 @@ func resourceProjectRead(ctx context.Context, d *schema.ResourceData, meta inter
 		return diag.FromErr(err)
 	}
 
+	err = d.Set("command_for_ignoring_build_step", project.CommandForIgnoringBuildStep)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	err = d.Set("git_fork_protection", project.GitForkProtection)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = d.Set("function_default_memory_type", project.ResourceConfig.FunctionDefaultMemoryType)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	err = d.Set("function_default_timeout", project.ResourceConfig.FunctionDefaultTimeout)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	err = d.Set("function_default_regions", project.ResourceConfig.FunctionDefaultRegions)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	err = d.Set("directory_listing", project.DirectoryListing)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	err = d.Set("auto_expose_system_envs", project.AutoExposeSystemEnvs)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	err = d.Set("source_files_outside_root_directory", project.SourceFilesOutsideRootDirectory)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	err = d.Set("skew_protection_max_age", project.SkewProtectionMaxAge)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	gitRepository := []map[string]interface{}{}
+	if project.Link.Type != "" {
+		gitRepository = append(gitRepository, map[string]interface{}{
+			"type": project.Link.Type,
+			"repo": repoFromLink(project),
+		})
+	}
+	err = d.Set("git_repository", gitRepository)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	// The branch is only tracked when it is configured, otherwise a new repository would inherit the old branch
+	if _, ok := d.GetOk("production_branch"); ok {
+		err = d.Set("production_branch", project.Link.ProductionBranch)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	aliases := make([]string, 0)
 	for i := 0; i < len(project.Aliases); i++ {
 		aliases = append(aliases, project.Aliases[i].Domain)
@@ -282,40 +444,91 @@ func resourceProjectRead(ctx context.Context, d *schema.ResourceData, meta inter
 func resourceProjectUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 
 	client := meta.(*vercel.Client)
+	teamId := d.Get("team_id").(string)
 	var update project.UpdateProject
 
+	if d.HasChange("git_repository") {
+		old, _ := d.GetChange("git_repository")
+		if len(old.([]interface{})) > 0 {
+			err := client.Project.Unlink(ctx, d.Id(), teamId)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+		}
+
+		repo := gitRepository(d)
+		if repo != nil {
+			err := client.Project.Link(ctx, d.Id(), *repo, teamId)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+
+			// Connecting a repository resets the production branch to its default branch, a configured branch is applied again
+			update.Branch = d.Get("production_branch").(string)
+		}
+	}
+	if d.HasChange("production_branch") {
+		update.Branch = d.Get("production_branch").(string)
+	}
 	if d.HasChange("name") {
 		update.Name = d.Get("name").(string)
 	}
 	if d.HasChange("framework") {
-		update.Framework = d.Get("framework").(string)
+		update.Framework = project.StringOrNull(d.Get("framework").(string))
 	}
 	if d.HasChange("public_source") {
-		update.PublicSource = d.Get("public_source").(bool)
+		publicSource := d.Get("public_source").(bool)
+		update.PublicSource = &publicSource
 	}
 	if d.HasChange("install_command") {
-		update.InstallCommand = d.Get("install_command").(string)
+		update.InstallCommand = project.StringOrNull(d.Get("install_command").(string))
 	}
 	if d.HasChange("build_command") {
-		update.BuildCommand = d.Get("build_command").(string)
+		update.BuildCommand = project.StringOrNull(d.Get("build_command").(string))
 	}
 	if d.HasChange("dev_command") {
-		update.DevCommand = d.Get("dev_command").(string)
+		update.DevCommand = project.StringOrNull(d.Get("dev_command").(string))
 	}
 	if d.HasChange("output_directory") {
-		update.OutputDirectory = d.Get("output_directory").(string)
+		update.OutputDirectory = project.StringOrNull(d.Get("output_directory").(string))
 	}
 	if d.HasChange("serverless_function_region") {
 		update.ServerlessFunctionRegion = d.Get("serverless_function_region").(string)
 	}
 	if d.HasChange("root_directory") {
-		update.RootDirectory = d.Get("root_directory").(string)
+		update.RootDirectory = project.StringOrNull(d.Get("root_directory").(string))
 	}
 	if d.HasChange("node_version") {
 		update.NodeVersion = d.Get("node_version").(string)
 	}
+	if d.HasChange("command_for_ignoring_build_step") {
+		update.CommandForIgnoringBuildStep = project.StringOrNull(d.Get("command_for_ignoring_build_step").(string))
+	}
+	if d.HasChange("git_fork_protection") {
+		gitForkProtection := d.Get("git_fork_protection").(bool)
+		update.GitForkProtection = &gitForkProtection
+	}
+	if d.HasChange("directory_listing") {
+		directoryListing := d.Get("directory_listing").(bool)
+		update.DirectoryListing = &directoryListing
+	}
+	if d.HasChange("auto_expose_system_envs") {
+		autoExposeSystemEnvs := d.Get("auto_expose_system_envs").(bool)
+		update.AutoExposeSystemEnvs = &autoExposeSystemEnvs
+	}
+	if d.HasChange("source_files_outside_root_directory") {
+		sourceFilesOutsideRootDirectory := d.Get("source_files_outside_root_directory").(bool)
+		update.SourceFilesOutsideRootDirectory = &sourceFilesOutsideRootDirectory
+	}
+	if d.HasChange("skew_protection_max_age") {
+		skewProtectionMaxAge := d.Get("skew_protection_max_age").(int)
+		update.SkewProtectionMaxAge = &skewProtectionMaxAge
+	}
+	if d.HasChanges("function_default_memory_type", "function_default_timeout", "function_default_regions") {
+		update.ResourceConfig = resourceConfig(d)
+	}
 
-	err := client.Project.Update(d.Id(), update, d.Get("team_id").(string))
+	err := client.Project.Update(ctx, d.Id(), update, teamId)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -326,9 +539,68 @@ func resourceProjectUpdate(ctx context.Context, d *schema.ResourceData, meta int
 func resourceProjectDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 
 	client := meta.(*vercel.Client)
-	err := client.Project.Delete(d.Id(), d.Get("team_id").(string))
+	err := client.Project.Delete(ctx, d.Id(), d.Get("team_id").(string))
 	if err != nil {
 		return diag.FromErr(err)
 	}
 	return diag.Diagnostics{}
 }
+
+// gitRepository returns the configured repository or nil when the project is not connected to one.
+func gitRepository(d *schema.ResourceData) *project.GitRepository {
+	// Terraform does not have nested objects with different types yet, so I am using a `TypeList`
+	// Here we have to typecast to list first and then take the first item and cast again.
+	repos := d.Get("git_repository").([]interface{})
+	if len(repos) == 0 || repos[0] == nil {
+		return nil
+	}
+	repo := repos[0].(map[string]interface{})
+	return &project.GitRepository{
+		Type: repo["type"].(string),
+		Repo: repo["repo"].(string),
+	}
+}
+
+// resourceConfig returns the configured function defaults or nil when none are known. The config is
+// always sent as a whole, so settings that are not configured keep the value that was last read.
+func resourceConfig(d *schema.ResourceData) *project.ResourceConfig {
+	config := project.ResourceConfig{
+		FunctionDefaultMemoryType: d.Get("function_default_memory_type").(string),
+		FunctionDefaultTimeout:    d.Get("function_default_timeout").(int),
+	}
+	for _, region := range d.Get("function_default_regions").(*schema.Set).List() {
+		config.FunctionDefaultRegions = append(config.FunctionDefaultRegions, region.(string))
+	}
+
+	if config.FunctionDefaultMemoryType == "" && config.FunctionDefaultTimeout == 0 && len(config.FunctionDefaultRegions) == 0 {
+		return nil
+	}
+	return &config
+}
+
+// repoFromLink builds the `owner/name` form of the connected repository, which differs per git provider.
+func repoFromLink(p project.Project) string {
+	switch p.Link.Type {
+	case "gitlab":
+		return fmt.Sprintf("%s/%s", p.Link.ProjectNamespace, p.Link.ProjectName)
+	case "bitbucket":
+		return fmt.Sprintf("%s/%s", p.Link.Owner, p.Link.Slug)
+	default:
+		return fmt.Sprintf("%s/%s", p.Link.Org, p.Link.Repo)
+	}
+}
+
+func resourceProjectImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	teamID, parts, err := splitImportID(d.Id(), 1, "`[team_id/]project_id`")
+	if err != nil {
+		return nil, err
+	}
+
+	err = d.Set("team_id", inheritTeamID(teamID, meta))
+	if err != nil {
+		return nil, err
+	}
+	d.SetId(parts[0])
+
+	return []*schema.ResourceData{d}, nil
+}
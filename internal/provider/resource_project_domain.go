@@ -7,6 +7,7 @@ import (
 	pdomain "github.com/chronark/terraform-provider-vercel/pkg/vercel/project_domain"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceProjectDomain() *schema.Resource {
@@ -17,6 +18,9 @@ func resourceProjectDomain() *schema.Resource {
 		UpdateContext: resourceProjectDomainUpdate,
 		ReadContext:   resourceProjectDomainRead,
 		DeleteContext: resourceProjectDomainDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceProjectDomainImport,
+		},
 
 		Schema: map[string]*schema.Schema{
 			"project_id": {
@@ -26,16 +30,17 @@ func resourceProjectDomain() *schema.Resource {
 				ForceNew:    true,
 			},
 			"team_id": {
-				Description: "By default, you can access resources contained within your own user account. To access resources owned by a team, you can pass in the team ID",
+				Description: "By default, you can access resources contained within your own user account. To access resources owned by a team, you can pass in the team ID. Defaults to the team configured in the provider.",
 				Type:        schema.TypeString,
 				Optional:    true,
+				Computed:    true,
 				ForceNew:    true,
-				Default:     "",
 			},
 			"name": {
 				Description: "The name of the project domain.",
 				Type:        schema.TypeString,
 				Required:    true,
+				ForceNew:    true,
 			},
 			"redirect": {
 				Description: "Target destination domain for redirect",
@@ -43,9 +48,11 @@ func resourceProjectDomain() *schema.Resource {
 				Optional:    true,
 			},
 			"redirect_status_code": {
-				Description: "The redirect status code (301, 302, 307, 308).",
-				Type:        schema.TypeInt,
-				Optional:    true,
+				Description:  "The redirect status code (301, 302, 307, 308).",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntInSlice([]int{301, 302, 307, 308}),
+				RequiredWith: []string{"redirect"},
 			},
 			"git_branch": {
 				Description: "Git branch for the domain to be auto assigned to. The Project's production branch is the default (null).",
@@ -62,6 +69,40 @@ func resourceProjectDomain() *schema.Resource {
 				Type:        schema.TypeInt,
 				Computed:    true,
 			},
+			"verified": {
+				Description: "Whether the ownership of the domain is verified. Requests to unverified domains are not served.",
+				Type:        schema.TypeBool,
+				Computed:    true,
+			},
+			"verification": {
+				Description: "The DNS records of which one has to be created to verify the domain, see `vercel_project_domain_verify`. Empty once the domain is verified.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"type": {
+							Description: "The type of the record, usually `TXT`.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"domain": {
+							Description: "The fully qualified name of the record.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"value": {
+							Description: "The value of the record.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"reason": {
+							Description: "Why the domain has to be verified.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+					},
+				},
+			},
 		},
 	}
 }
@@ -69,14 +110,21 @@ func resourceProjectDomain() *schema.Resource {
 func resourceProjectDomainRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*vercel.Client)
 
-	domain, err := client.ProjectDomain.Read(d.Get("project_id").(string), d.Get("team_id").(string), d.Get("name").(string))
+	domain, err := client.ProjectDomain.Read(ctx, d.Get("project_id").(string), d.Get("team_id").(string), d.Get("name").(string))
 
 	if err != nil {
+		if removedOutsideTerraform(d, err, "project domain") {
+			return diag.Diagnostics{}
+		}
 		return diag.FromErr(err)
 	}
 
 	d.SetId(domain.Name)
 
+	if err := d.Set("project_id", domain.ProjectID); err != nil {
+		return diag.FromErr(err)
+	}
+
 	if err := d.Set("name", domain.Name); err != nil {
 		return diag.FromErr(err)
 	}
@@ -101,6 +149,23 @@ func resourceProjectDomainRead(ctx context.Context, d *schema.ResourceData, meta
 		return diag.FromErr(err)
 	}
 
+	if err := d.Set("verified", domain.Verified); err != nil {
+		return diag.FromErr(err)
+	}
+
+	verification := []map[string]interface{}{}
+	for _, v := range domain.Verification {
+		verification = append(verification, map[string]interface{}{
+			"type":   v.Type,
+			"domain": v.Domain,
+			"value":  v.Value,
+			"reason": v.Reason,
+		})
+	}
+	if err := d.Set("verification", verification); err != nil {
+		return diag.FromErr(err)
+	}
+
 	return diag.Diagnostics{}
 }
 
@@ -129,7 +194,12 @@ func resourceProjectDomainCreate(ctx context.Context, d *schema.ResourceData, me
 
 	dto := toCreateOrUpdateProjectDomain(d)
 
-	if _, err := client.ProjectDomain.Create(d.Get("project_id").(string), d.Get("team_id").(string), dto); err != nil {
+	teamID := inheritTeamID(d.Get("team_id").(string), meta)
+	if err := d.Set("team_id", teamID); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if _, err := client.ProjectDomain.Create(ctx, d.Get("project_id").(string), teamID, dto); err != nil {
 		return diag.FromErr(err)
 	}
 
@@ -139,7 +209,7 @@ func resourceProjectDomainCreate(ctx context.Context, d *schema.ResourceData, me
 func resourceProjectDomainDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*vercel.Client)
 
-	if err := client.ProjectDomain.Delete(d.Get("project_id").(string), d.Get("team_id").(string), d.Get("name").(string)); err != nil {
+	if err := client.ProjectDomain.Delete(ctx, d.Get("project_id").(string), d.Get("team_id").(string), d.Get("name").(string)); err != nil {
 		return diag.FromErr(err)
 	}
 
@@ -154,7 +224,7 @@ func resourceProjectDomainUpdate(ctx context.Context, d *schema.ResourceData, me
 	if d.HasChanges("redirect", "redirect_status_code", "git_branch") {
 		dto := toCreateOrUpdateProjectDomain(d)
 
-		domain, err := client.ProjectDomain.Update(d.Get("project_id").(string), d.Get("team_id").(string), d.Id(), dto)
+		domain, err := client.ProjectDomain.Update(ctx, d.Get("project_id").(string), d.Get("team_id").(string), d.Id(), dto)
 
 		if err != nil {
 			return diag.FromErr(err)
@@ -167,3 +237,26 @@ func resourceProjectDomainUpdate(ctx context.Context, d *schema.ResourceData, me
 
 	return diag.Diagnostics{}
 }
+
+func resourceProjectDomainImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	teamID, parts, err := splitImportID(d.Id(), 2, "`[team_id/]project_id/name`")
+	if err != nil {
+		return nil, err
+	}
+
+	if err := d.Set("team_id", inheritTeamID(teamID, meta)); err != nil {
+		return nil, err
+	}
+
+	if err := d.Set("project_id", parts[0]); err != nil {
+		return nil, err
+	}
+
+	if err := d.Set("name", parts[1]); err != nil {
+		return nil, err
+	}
+
+	d.SetId(parts[1])
+
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,114 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccVercelProjectDomainVerify(t *testing.T) {
+	projectName := "acceptance-test-project-domain"
+	domainName := "acceptancetestverify.com"
+
+	// Verifying needs a domain we own
+	testAccFakeOnly(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			testAccCheckVercelProjectDestroy("vercel_project"),
+			testAccCheckVercelDomainDestroy(domainName),
+		),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckVercelProjectDomainConfig(projectName, domainName, ""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vercel_project_domain.www", "verified", "false"),
+					resource.TestCheckResourceAttr("vercel_project_domain.www", "verification.0.type", "TXT"),
+					resource.TestCheckResourceAttr("vercel_project_domain.www", "verification.0.domain", "_vercel."+domainName),
+				),
+			},
+			{
+				Config: testAccCheckVercelProjectDomainConfig(projectName, domainName, testAccCheckVercelProjectDomainVerifyConfig),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vercel_project_domain_verify.www", "verified", "true"),
+				),
+			},
+			{
+				// Changing the redirect keeps the domain and its verification
+				Config: testAccCheckVercelProjectDomainConfig(projectName, domainName, testAccCheckVercelProjectDomainVerifyConfig+`
+				resource "vercel_project_domain" "apex" {
+					project_id           = vercel_project.new.id
+					name                 = "`+domainName+`"
+					redirect             = vercel_project_domain.www.name
+					redirect_status_code = 308
+				}
+				`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vercel_project_domain.apex", "redirect", "www."+domainName),
+					resource.TestCheckResourceAttr("vercel_project_domain.apex", "redirect_status_code", "308"),
+					resource.TestCheckResourceAttr("vercel_project_domain.www", "verified", "true"),
+					resource.TestCheckResourceAttr("vercel_project_domain.www", "verification.#", "0"),
+				),
+			},
+			{
+				ResourceName:      "vercel_project_domain.www",
+				ImportState:       true,
+				ImportStateIdFunc: testAccProjectDomainImportID("vercel_project_domain.www"),
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+// The challenge disappears from the project domain once it is verified, so the TXT record keeps its first value.
+const testAccCheckVercelProjectDomainVerifyConfig = `
+	resource "vercel_dns" "challenge" {
+		domain = vercel_domain.apex.name
+		type   = "TXT"
+		name   = "_vercel"
+		value  = try(vercel_project_domain.www.verification[0].value, "verified")
+		ttl    = 60
+
+		lifecycle {
+			ignore_changes = [value]
+		}
+	}
+
+	resource "vercel_project_domain_verify" "www" {
+		project_id = vercel_project.new.id
+		name       = vercel_project_domain.www.name
+		depends_on = [vercel_dns.challenge]
+	}
+`
+
+func testAccCheckVercelProjectDomainConfig(projectName, domainName, extra string) string {
+	return fmt.Sprintf(`
+	resource "vercel_project" "new" {
+		name = "%s"
+	}
+
+	resource "vercel_domain" "apex" {
+		name = "%s"
+	}
+
+	resource "vercel_project_domain" "www" {
+		project_id = vercel_project.new.id
+		name       = "www.${vercel_domain.apex.name}"
+	}
+	%s
+	`, projectName, domainName, extra)
+}
+
+func testAccProjectDomainImportID(name string) resource.ImportStateIdFunc {
+	return func(s *terraform.State) (string, error) {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return "", fmt.Errorf("Not found: %s", name)
+		}
+		return fmt.Sprintf("%s/%s", rs.Primary.Attributes["project_id"], rs.Primary.ID), nil
+	}
+}
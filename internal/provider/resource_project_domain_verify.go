@@ -0,0 +1,114 @@
+package provider
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/chronark/terraform-provider-vercel/pkg/vercel"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceProjectDomainVerify() *schema.Resource {
+	return &schema.Resource{
+		Description: "Verifies the ownership of a project domain. Creating this resource waits until the verification challenge of `vercel_project_domain` is satisfied, usually by a TXT record. Destroying it does not change the domain.",
+
+		CreateContext: resourceProjectDomainVerifyCreate,
+		ReadContext:   resourceProjectDomainVerifyRead,
+		DeleteContext: resourceProjectDomainVerifyDelete,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"project_id": {
+				Description: "The unique Project identifier.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"team_id": {
+				Description: "By default, you can access resources contained within your own user account. To access resources owned by a team, you can pass in the team ID. Defaults to the team configured in the provider.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+			},
+			"name": {
+				Description: "The name of the project domain.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"verified": {
+				Description: "Whether the ownership of the domain is verified.",
+				Type:        schema.TypeBool,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func resourceProjectDomainVerifyCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*vercel.Client)
+	projectID := d.Get("project_id").(string)
+	name := d.Get("name").(string)
+
+	teamID := inheritTeamID(d.Get("team_id").(string), meta)
+	if err := d.Set("team_id", teamID); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := waitForProjectDomainVerification(ctx, client, projectID, teamID, name, d.Timeout(schema.TimeoutCreate)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(name)
+
+	return resourceProjectDomainVerifyRead(ctx, d, meta)
+}
+
+// waitForProjectDomainVerification asks vercel to check the verification challenge until it succeeds.
+func waitForProjectDomainVerification(ctx context.Context, client *vercel.Client, projectID, teamID, name string, timeout time.Duration) error {
+	return waitForVerification(ctx, "project domain", name, timeout, func() error {
+		domain, err := client.ProjectDomain.Read(ctx, projectID, teamID, name)
+		if err != nil || domain.Verified {
+			return err
+		}
+		_, err = client.ProjectDomain.Verify(ctx, projectID, teamID, name)
+		return err
+	})
+}
+
+func resourceProjectDomainVerifyRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*vercel.Client)
+
+	domain, err := client.ProjectDomain.Read(ctx, d.Get("project_id").(string), d.Get("team_id").(string), d.Get("name").(string))
+	if err != nil {
+		if removedOutsideTerraform(d, err, "project domain") {
+			return diag.Diagnostics{}
+		}
+		return diag.FromErr(err)
+	}
+
+	// A domain that lost its verification is verified again by the next apply
+	if !domain.Verified && !d.IsNewResource() {
+		log.Printf("[WARN] project domain %s is no longer verified, removing the verification from state", domain.Name)
+		d.SetId("")
+		return diag.Diagnostics{}
+	}
+
+	if err := d.Set("verified", domain.Verified); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diag.Diagnostics{}
+}
+
+// Verification can not be undone, destroying the resource only removes it from the state.
+func resourceProjectDomainVerifyDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	d.SetId("")
+	return diag.Diagnostics{}
+}
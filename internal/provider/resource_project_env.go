@@ -0,0 +1,342 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/chronark/terraform-provider-vercel/pkg/vercel"
+	"github.com/chronark/terraform-provider-vercel/pkg/vercel/env"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// The targets a variable can apply to.
+var envTargets = []string{"development", "preview", "production"}
+
+func resourceProjectEnv() *schema.Resource {
+	return &schema.Resource{
+		Description: "Manages many environment variables of a project at once. Variables are compared with a single request, new variables are created in batches. Do not combine this resource with `vercel_env` for the same variables.",
+
+		CreateContext: resourceProjectEnvCreate,
+		ReadContext:   resourceProjectEnvRead,
+		UpdateContext: resourceProjectEnvUpdate,
+		DeleteContext: resourceProjectEnvDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceProjectEnvImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"project_id": {
+				Description: "The unique project identifier.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"team_id": {
+				Description: "By default, you can access resources contained within your own user account. To access resources owned by a team, you can pass in the team ID. Defaults to the team configured in the provider.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+			},
+			"variable": {
+				Description: "An environment variable of the project.",
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"key": {
+							Description: "The name of the environment variable.",
+							Type:        schema.TypeString,
+							Required:    true,
+						},
+						"value": {
+							Description: "The value of the environment variable, see `vercel_env` for the meaning of the value for each type.",
+							Type:        schema.TypeString,
+							Required:    true,
+							Sensitive:   true,
+						},
+						"type": {
+							Description:  "The type can be `plain`, `secret`, `system`, `encrypted` or `sensitive`.",
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      env.TypeEncrypted,
+							ValidateFunc: validation.StringInSlice(env.Types, false),
+						},
+						"target": {
+							Description: "The targets the variable applies to, any of `development`, `preview` or `production`.",
+							Type:        schema.TypeSet,
+							Required:    true,
+							Elem: &schema.Schema{
+								Type:         schema.TypeString,
+								ValidateFunc: validation.StringInSlice(envTargets, false),
+							},
+						},
+						"git_branch": {
+							Description: "The Git branch for this variable, only accepted when the target is exclusively preview.",
+							Type:        schema.TypeString,
+							Optional:    true,
+						},
+					},
+				},
+			},
+			"remove_unmanaged": {
+				Description: "Whether variables of the project that are not part of the configuration are deleted. By default only variables that were created or adopted by this resource are deleted.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+			"managed": {
+				Description: "The variables managed by this resource as they exist in vercel, without their values.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Description: "Unique id for this variable.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"key": {
+							Description: "The name of the environment variable.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"updated_at": {
+							Description: "A number containing the date when the variable was updated in milliseconds.",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// desiredProjectEnvs returns the configured variables.
+func desiredProjectEnvs(d *schema.ResourceData) []env.CreateOrUpdateEnv {
+	envs := []env.CreateOrUpdateEnv{}
+	for _, raw := range d.Get("variable").(*schema.Set).List() {
+		block := raw.(map[string]interface{})
+		e := env.CreateOrUpdateEnv{
+			Type:   block["type"].(string),
+			Key:    block["key"].(string),
+			Value:  block["value"].(string),
+			Target: []string{},
+		}
+		for _, target := range block["target"].(*schema.Set).List() {
+			e.Target = append(e.Target, target.(string))
+		}
+		if b := block["git_branch"].(string); b != "" {
+			e.GitBranch = &b
+		}
+		envs = append(envs, e)
+	}
+	return envs
+}
+
+// knownProjectEnvs returns the values terraform last wrote, by scope, and when each managed variable was last updated, by id.
+func knownProjectEnvs(variables *schema.Set, managed []interface{}) (values map[string]string, updatedAt map[string]int64) {
+	values = map[string]string{}
+	for _, raw := range variables.List() {
+		block := raw.(map[string]interface{})
+		e := env.Env{Key: block["key"].(string), GitBranch: block["git_branch"].(string)}
+		for _, target := range block["target"].(*schema.Set).List() {
+			e.Target = append(e.Target, target.(string))
+		}
+		values[e.Scope()] = block["value"].(string)
+	}
+
+	updatedAt = map[string]int64{}
+	for _, raw := range managed {
+		block := raw.(map[string]interface{})
+		updatedAt[block["id"].(string)] = int64(block["updated_at"].(int))
+	}
+	return values, updatedAt
+}
+
+// withKnownValues fills in the values vercel does not return. A variable that was updated since terraform
+// last wrote it keeps an empty value, so it is written again.
+func withKnownValues(existing []env.Env, values map[string]string, updatedAt map[string]int64) []env.Env {
+	filled := make([]env.Env, len(existing))
+	for i, e := range existing {
+		if env.HidesValue(e.Type) {
+			if at, ok := updatedAt[e.ID]; ok && at == e.UpdatedAt {
+				e.Value = values[e.Scope()]
+			}
+		}
+		filled[i] = e
+	}
+	return filled
+}
+
+// applyProjectEnv compares the configured variables with the variables in vercel and makes the necessary changes.
+func applyProjectEnv(ctx context.Context, d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*vercel.Client)
+	projectID := d.Get("project_id").(string)
+	teamId := d.Get("team_id").(string)
+
+	existing, err := client.Env.Read(ctx, projectID, teamId)
+	if err != nil {
+		return err
+	}
+
+	oldVariables, _ := d.GetChange("variable")
+	values, updatedAt := knownProjectEnvs(oldVariables.(*schema.Set), d.Get("managed").([]interface{}))
+	managed := func(e env.Env) bool {
+		_, ok := updatedAt[e.ID]
+		return ok || d.Get("remove_unmanaged").(bool)
+	}
+	changes := env.DiffManaged(desiredProjectEnvs(d), withKnownValues(existing, values, updatedAt), managed)
+
+	return client.Env.Apply(ctx, projectID, changes, teamId)
+}
+
+func resourceProjectEnvCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	teamId := inheritTeamID(d.Get("team_id").(string), meta)
+	err := d.Set("team_id", teamId)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = applyProjectEnv(ctx, d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(d.Get("project_id").(string))
+
+	return readProjectEnv(ctx, d, meta, true)
+}
+
+func resourceProjectEnvRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return readProjectEnv(ctx, d, meta, false)
+}
+
+// readProjectEnv refreshes the managed variables with a single request. written is true right after terraform
+// changed the variables, then every variable of the configuration is managed and its value is known.
+func readProjectEnv(ctx context.Context, d *schema.ResourceData, meta interface{}, written bool) diag.Diagnostics {
+	client := meta.(*vercel.Client)
+
+	existing, err := client.Env.Read(ctx, d.Get("project_id").(string), d.Get("team_id").(string))
+	if err != nil {
+		if removedOutsideTerraform(d, err, "project environment") {
+			return diag.Diagnostics{}
+		}
+		return diag.FromErr(err)
+	}
+
+	values, updatedAt := knownProjectEnvs(d.Get("variable").(*schema.Set), d.Get("managed").([]interface{}))
+	removeUnmanaged := d.Get("remove_unmanaged").(bool)
+
+	variables := []interface{}{}
+	managed := []interface{}{}
+	for _, e := range existing {
+		_, isManaged := updatedAt[e.ID]
+		_, isConfigured := values[e.Scope()]
+		if written {
+			isManaged = isConfigured
+		}
+		if !isManaged && !removeUnmanaged {
+			continue
+		}
+
+		value := e.Value
+		if env.HidesValue(e.Type) {
+			value = ""
+			if written || updatedAt[e.ID] == e.UpdatedAt {
+				value = values[e.Scope()]
+			}
+		}
+
+		variables = append(variables, map[string]interface{}{
+			"key":        e.Key,
+			"value":      value,
+			"type":       e.Type,
+			"target":     e.Target,
+			"git_branch": e.GitBranch,
+		})
+		managed = append(managed, map[string]interface{}{
+			"id":         e.ID,
+			"key":        e.Key,
+			"updated_at": e.UpdatedAt,
+		})
+	}
+
+	err = d.Set("variable", variables)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	err = d.Set("managed", managed)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diag.Diagnostics{}
+}
+
+func resourceProjectEnvUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	err := applyProjectEnv(ctx, d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return readProjectEnv(ctx, d, meta, true)
+}
+
+// resourceProjectEnvDelete removes the managed variables, other variables of the project are kept.
+func resourceProjectEnvDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*vercel.Client)
+
+	changes := env.Changes{}
+	for _, raw := range d.Get("managed").([]interface{}) {
+		changes.Delete = append(changes.Delete, env.Env{ID: raw.(map[string]interface{})["id"].(string)})
+	}
+
+	err := client.Env.Apply(ctx, d.Get("project_id").(string), changes, d.Get("team_id").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+	return diag.Diagnostics{}
+}
+
+// resourceProjectEnvImport adopts all variables of the project. The values of encrypted and sensitive
+// variables are unknown, so they are written again by the next apply.
+func resourceProjectEnvImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	client := meta.(*vercel.Client)
+
+	teamID, parts, err := splitImportID(d.Id(), 1, "`[team_id/]project_id`")
+	if err != nil {
+		return nil, err
+	}
+	teamID = inheritTeamID(teamID, meta)
+
+	err = d.Set("team_id", teamID)
+	if err != nil {
+		return nil, err
+	}
+	err = d.Set("project_id", parts[0])
+	if err != nil {
+		return nil, err
+	}
+	d.SetId(parts[0])
+
+	existing, err := client.Env.Read(ctx, parts[0], teamID)
+	if err != nil {
+		return nil, err
+	}
+	managed := []interface{}{}
+	for _, e := range existing {
+		// An outdated timestamp marks the value as unknown
+		managed = append(managed, map[string]interface{}{"id": e.ID, "key": e.Key, "updated_at": 0})
+	}
+	err = d.Set("managed", managed)
+	if err != nil {
+		return nil, err
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
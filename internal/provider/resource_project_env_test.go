@@ -0,0 +1,105 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/chronark/terraform-provider-vercel/pkg/vercel/env"
+	"github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccVercelProjectEnv(t *testing.T) {
+	projectName, _ := uuid.GenerateUUID()
+	var projectID string
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testAccCheckVercelProjectDestroy(projectName),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckVercelProjectEnvConfig(projectName, "hunter2", false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vercel_project_env.all", "managed.#", "2"),
+					func(s *terraform.State) error {
+						projectID = s.RootModule().Resources["vercel_project.new"].Primary.ID
+						return nil
+					},
+				),
+			},
+			{
+				// Unmanaged variables are kept by default
+				PreConfig: func() {
+					_, err := testAccClient().Env.Create(context.Background(), projectID, env.CreateOrUpdateEnv{
+						Type:   env.TypePlain,
+						Key:    "MANUAL",
+						Value:  "manual",
+						Target: []string{"production"},
+					}, "")
+					if err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config: testAccCheckVercelProjectEnvConfig(projectName, "changed", false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vercel_project_env.all", "managed.#", "2"),
+					testAccCheckProjectEnvCount(&projectID, 3),
+				),
+			},
+			{
+				Config: testAccCheckVercelProjectEnvConfig(projectName, "changed", true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vercel_project_env.all", "managed.#", "2"),
+					testAccCheckProjectEnvCount(&projectID, 2),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckProjectEnvCount(projectID *string, want int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		envs, err := testAccClient().Env.Read(context.Background(), *projectID, "")
+		if err != nil {
+			return err
+		}
+		if len(envs) != want {
+			return fmt.Errorf("expected %d variables, got %d", want, len(envs))
+		}
+		return nil
+	}
+}
+
+func testAccCheckVercelProjectEnvConfig(projectName string, token string, removeUnmanaged bool) string {
+	return fmt.Sprintf(`
+	resource "vercel_project" "new" {
+		name = "%s"
+		git_repository {
+			type = "github"
+			repo = "chronark/terraform-provider-vercel"
+		}
+	}
+
+	resource "vercel_project_env" "all" {
+		project_id       = vercel_project.new.id
+		remove_unmanaged = %t
+
+		variable {
+			key    = "TOKEN"
+			value  = "%s"
+			type   = "sensitive"
+			target = ["production", "preview"]
+		}
+
+		variable {
+			key    = "PUBLIC_URL"
+			value  = "https://chronark.com"
+			type   = "plain"
+			target = ["production"]
+		}
+	}
+	`, projectName, removeUnmanaged, token)
+}
@@ -1,13 +1,12 @@
 package provider
 
 import (
+	"context"
 	"fmt"
-	"github.com/chronark/terraform-provider-vercel/pkg/vercel"
 	"github.com/chronark/terraform-provider-vercel/pkg/vercel/project"
 	"github.com/hashicorp/go-uuid"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
-	"os"
 	"testing"
 )
 
@@ -52,6 +51,11 @@ func TestAccVercelProject(t *testing.T) {
 					testAccCheckProjectWasNotRecreated(&actualProjectAfterCreation, &actualProjectAfterUpdate),
 				),
 			},
+			{
+				ResourceName:      "vercel_project.new",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
 			{
 				Config: testAccCheckVercelProjectConfigWithOverridenCommands(projectName),
 				Check: resource.ComposeTestCheckFunc(
@@ -75,6 +79,34 @@ func TestAccVercelProject(t *testing.T) {
 					),
 				),
 			},
+			{
+				Config: testAccCheckVercelProjectConfigWithGitSettings(projectName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckVercelProjectExists("vercel_project.new", &actualProjectAfterUpdate),
+					testAccCheckProjectWasNotRecreated(&actualProjectAfterCreation, &actualProjectAfterUpdate),
+					resource.TestCheckResourceAttr("vercel_project.new", "git_repository.0.repo", "chronark/venus"),
+					resource.TestCheckResourceAttr("vercel_project.new", "production_branch", "production"),
+					resource.TestCheckResourceAttr("vercel_project.new", "command_for_ignoring_build_step", "exit 0"),
+					resource.TestCheckResourceAttr("vercel_project.new", "git_fork_protection", "false"),
+					// Removing the commands from the configuration restores auto detection
+					resource.TestCheckResourceAttr("vercel_project.new", "build_command", ""),
+					resource.TestCheckResourceAttr("vercel_project.new", "output_directory", ""),
+				),
+			},
+			{
+				Config: testAccCheckVercelProjectConfigWithFunctionSettings(projectName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckVercelProjectExists("vercel_project.new", &actualProjectAfterUpdate),
+					testAccCheckProjectWasNotRecreated(&actualProjectAfterCreation, &actualProjectAfterUpdate),
+					resource.TestCheckResourceAttr("vercel_project.new", "function_default_memory_type", "performance"),
+					resource.TestCheckResourceAttr("vercel_project.new", "function_default_timeout", "60"),
+					resource.TestCheckResourceAttr("vercel_project.new", "function_default_regions.#", "2"),
+					resource.TestCheckResourceAttr("vercel_project.new", "directory_listing", "true"),
+					resource.TestCheckResourceAttr("vercel_project.new", "auto_expose_system_envs", "false"),
+					resource.TestCheckResourceAttr("vercel_project.new", "source_files_outside_root_directory", "false"),
+					resource.TestCheckResourceAttr("vercel_project.new", "skew_protection_max_age", "3600"),
+				),
+			},
 		},
 	})
 }
@@ -144,17 +176,17 @@ func testAccCheckActualProjectHasValues(actual *project.Project, want *project.P
 // Test whether the project was destroyed properly and finishes the job if necessary
 func testAccCheckVercelProjectDestroy(name string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
-		client := vercel.New(os.Getenv("VERCEL_TOKEN"))
+		client := testAccClient()
 
 		for _, rs := range s.RootModule().Resources {
 			if rs.Type != name {
 				continue
 			}
 
-			project, err := client.Project.Read(rs.Primary.ID, "")
+			project, err := client.Project.Read(context.Background(), rs.Primary.ID, "")
 			if err == nil {
 				message := "Project was not deleted from vercel during terraform destroy."
-				deleteErr := client.Project.Delete(project.Name, "")
+				deleteErr := client.Project.Delete(context.Background(), project.Name, "")
 				if deleteErr != nil {
 					return fmt.Errorf(message+" Automated removal did not succeed. Please manually remove @%s. Error: %w", project.Name, err)
 				}
@@ -194,6 +226,41 @@ func testAccCheckVercelProjectConfigWithOverridenCommands(name string) string {
 	`, name)
 }
 
+func testAccCheckVercelProjectConfigWithGitSettings(name string) string {
+	return fmt.Sprintf(`
+	resource "vercel_project" "new" {
+		name = "%s"
+		git_repository {
+			type = "github"
+			repo = "chronark/venus"
+		}
+		production_branch               = "production"
+		command_for_ignoring_build_step = "exit 0"
+		git_fork_protection             = false
+	}
+	`, name)
+}
+
+func testAccCheckVercelProjectConfigWithFunctionSettings(name string) string {
+	return fmt.Sprintf(`
+	resource "vercel_project" "new" {
+		name = "%s"
+		git_repository {
+			type = "github"
+			repo = "chronark/mercury"
+		}
+		command_for_ignoring_build_step     = "exit 0"
+		function_default_memory_type        = "performance"
+		function_default_timeout            = 60
+		function_default_regions            = ["fra1", "iad1"]
+		directory_listing                   = true
+		auto_expose_system_envs             = false
+		source_files_outside_root_directory = false
+		skew_protection_max_age             = 3600
+	}
+	`, name)
+}
+
 func testAccCheckVercelProjectExists(n string, actual *project.Project) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[n]
@@ -206,7 +273,7 @@ func testAccCheckVercelProjectExists(n string, actual *project.Project) resource
 			return fmt.Errorf("No project set")
 		}
 
-		project, err := vercel.New(os.Getenv("VERCEL_TOKEN")).Project.Read(rs.Primary.ID, "")
+		project, err := testAccClient().Project.Read(context.Background(), rs.Primary.ID, "")
 		if err != nil {
 			return err
 		}
@@ -15,6 +15,9 @@ func resourceSecret() *schema.Resource {
 		CreateContext: resourceSecretCreate,
 		ReadContext:   resourceSecretRead,
 		DeleteContext: resourceSecretDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceSecretImport,
+		},
 
 		Schema: map[string]*schema.Schema{
 			"id": {
@@ -23,11 +26,11 @@ func resourceSecret() *schema.Resource {
 				Computed:    true,
 			},
 			"team_id": {
-				Description: "By default, you can access resources contained within your own user account. To access resources owned by a team, you can pass in the team ID",
+				Description: "By default, you can access resources contained within your own user account. To access resources owned by a team, you can pass in the team ID. Defaults to the team configured in the provider.",
 				Type:        schema.TypeString,
 				Optional:    true,
+				Computed:    true,
 				ForceNew:    true,
-				Default:     "",
 			},
 			"name": {
 				Description: "The name of the secret.",
@@ -66,7 +69,13 @@ func resourceSecretCreate(ctx context.Context, d *schema.ResourceData, meta inte
 		Value: d.Get("value").(string),
 	}
 
-	secretID, err := client.Secret.Create(payload)
+	teamId := inheritTeamID(d.Get("team_id").(string), meta)
+	err := d.Set("team_id", teamId)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	secretID, err := client.Secret.Create(ctx, payload, teamId)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -81,8 +90,11 @@ func resourceSecretRead(ctx context.Context, d *schema.ResourceData, meta interf
 
 	id := d.Id()
 
-	secret, err := client.Secret.Read(id, d.Get("team_id").(string))
+	secret, err := client.Secret.Read(ctx, id, d.Get("team_id").(string))
 	if err != nil {
+		if removedOutsideTerraform(d, err, "secret") {
+			return diag.Diagnostics{}
+		}
 		return diag.FromErr(err)
 	}
 
@@ -110,10 +122,26 @@ func resourceSecretDelete(ctx context.Context, d *schema.ResourceData, meta inte
 
 	client := meta.(*vercel.Client)
 
-	err := client.Secret.Delete(d.Get("name").(string), d.Get("team_id").(string))
+	err := client.Secret.Delete(ctx, d.Get("name").(string), d.Get("team_id").(string))
 	if err != nil {
 		return diag.FromErr(err)
 	}
 	d.SetId("")
 	return diag.Diagnostics{}
 }
+
+// The value of a secret can never be read back from vercel, so it stays empty after an import.
+func resourceSecretImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	teamID, parts, err := splitImportID(d.Id(), 1, "`[team_id/]secret_id`")
+	if err != nil {
+		return nil, err
+	}
+
+	err = d.Set("team_id", inheritTeamID(teamID, meta))
+	if err != nil {
+		return nil, err
+	}
+	d.SetId(parts[0])
+
+	return []*schema.ResourceData{d}, nil
+}
@@ -1,13 +1,12 @@
 package provider
 
 import (
+	"context"
 	"fmt"
-	"github.com/chronark/terraform-provider-vercel/pkg/vercel"
 	"github.com/chronark/terraform-provider-vercel/pkg/vercel/secret"
 	"github.com/hashicorp/go-uuid"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
-	"os"
 	"testing"
 )
 
@@ -50,6 +49,13 @@ func TestAccVercelSecret(t *testing.T) {
 					testAccCheckSecretWasRecreated(&actualSecretAfterCreation, &actualSecretAfterUpdate),
 				),
 			},
+			{
+				ResourceName:      "vercel_secret.new",
+				ImportState:       true,
+				ImportStateVerify: true,
+				// The value of a secret is never returned by vercel
+				ImportStateVerifyIgnore: []string{"value"},
+			},
 		},
 	})
 }
@@ -101,17 +107,17 @@ func testAccCheckActualSecretHasValues(actual *secret.Secret, want *secret.Secre
 // Test whether the secret was destroyed properly and finishes the job if necessary
 func testAccCheckVercelSecretDestroy(name string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
-		client := vercel.New(os.Getenv("VERCEL_TOKEN"))
+		client := testAccClient()
 
 		for _, rs := range s.RootModule().Resources {
 			if rs.Type != name {
 				continue
 			}
 
-			secret, err := client.Secret.Read(rs.Primary.ID, "")
+			secret, err := client.Secret.Read(context.Background(), rs.Primary.ID, "")
 			if err == nil {
 				message := "Secret was not deleted from vercel during terraform destroy."
-				deleteErr := client.Secret.Delete(secret.Name, "")
+				deleteErr := client.Secret.Delete(context.Background(), secret.Name, "")
 				if deleteErr != nil {
 					return fmt.Errorf(message+" Automated removal did not succeed. Please manually remove @%s. Error: %w", secret.Name, err)
 				}
@@ -144,7 +150,7 @@ func testAccCheckVercelSecretExists(n string, actual *secret.Secret) resource.Te
 			return fmt.Errorf("No secret set")
 		}
 
-		secret, err := vercel.New(os.Getenv("VERCEL_TOKEN")).Secret.Read(rs.Primary.ID, "")
+		secret, err := testAccClient().Secret.Read(context.Background(), rs.Primary.ID, "")
 		if err != nil {
 			return err
 		}
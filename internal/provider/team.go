@@ -0,0 +1,15 @@
+package provider
+
+import (
+	"github.com/chronark/terraform-provider-vercel/pkg/vercel"
+)
+
+// inheritTeamID falls back to the team configured in the provider when a resource does not specify a team itself.
+// It must only be used when a resource is created or imported. Afterwards the `team_id` in the state is the only
+// source of truth, so changing the team of the provider does not affect existing resources.
+func inheritTeamID(teamID string, meta interface{}) string {
+	if teamID != "" {
+		return teamID
+	}
+	return meta.(*vercel.Client).TeamID
+}
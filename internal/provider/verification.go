@@ -0,0 +1,35 @@
+package provider
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/chronark/terraform-provider-vercel/pkg/vercel/httpApi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+// waitForVerification calls verify until it succeeds. DNS changes take a while to propagate, so checks that
+// vercel reports as failed are retried until the timeout. Every other error stops waiting.
+func waitForVerification(ctx context.Context, kind, name string, timeout time.Duration, verify func() error) error {
+	conf := &resource.StateChangeConf{
+		Pending: []string{"pending"},
+		Target:  []string{"verified"},
+		Refresh: func() (interface{}, string, error) {
+			err := verify()
+			if err != nil {
+				if httpApi.IsVerificationFailed(err) {
+					log.Printf("[DEBUG] %s %s is not verified yet: %s", kind, name, err)
+					return name, "pending", nil
+				}
+				return nil, "", err
+			}
+			return name, "verified", nil
+		},
+		Timeout:    timeout,
+		MinTimeout: 5 * time.Second,
+	}
+
+	_, err := conf.WaitForStateContext(ctx)
+	return err
+}
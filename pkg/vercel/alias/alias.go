@@ -1,11 +1,14 @@
 package alias
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
 
 	"github.com/chronark/terraform-provider-vercel/pkg/vercel/httpApi"
+	"github.com/chronark/terraform-provider-vercel/pkg/vercel/pagination"
 )
 
 type CreateOrUpdateAlias struct {
@@ -31,13 +34,13 @@ type Handler struct {
 	Api httpApi.API
 }
 
-func (h *Handler) Create(projectId string, alias CreateOrUpdateAlias, teamId string) error {
+func (h *Handler) Create(ctx context.Context, projectId string, alias CreateOrUpdateAlias, teamId string) error {
 	url := fmt.Sprintf("/v1/projects/%s/alias", projectId)
 	if teamId != "" {
 		url = fmt.Sprintf("%s/?teamId=%s", url, teamId)
 	}
 
-	res, err := h.Api.Request(http.MethodPost, url, CreateOrUpdateAlias{
+	res, err := h.Api.RequestWithContext(ctx, http.MethodPost, url, CreateOrUpdateAlias{
 		Domain:             alias.Domain,
 		Redirect:           alias.Redirect,
 		RedirectStatusCode: alias.RedirectStatusCode,
@@ -56,56 +59,67 @@ func (h *Handler) Create(projectId string, alias CreateOrUpdateAlias, teamId str
 	return nil
 }
 
-func (h *Handler) Read(projectId string, domain, teamId string) (Alias, error) {
-	url := fmt.Sprintf("/v1/projects/%s", projectId)
+// Read finds the alias among the domains of the project
+func (h *Handler) Read(ctx context.Context, projectId string, domain, teamId string) (Alias, error) {
+	query := url.Values{}
 	if teamId != "" {
-		url = fmt.Sprintf("%s/?teamId=%s", url, teamId)
-	}
-
-	res, err := h.Api.Request("GET", url, nil)
-	if err != nil {
-		return Alias{}, fmt.Errorf("Unable to fetch project from vercel: %w", err)
-	}
-	defer res.Body.Close()
-	type Project struct {
-		Aliases []Alias `json:"alias"`
+		query.Set("teamId", teamId)
 	}
-	project := Project{}
 
-	err = json.NewDecoder(res.Body).Decode(&project)
-	if err != nil {
-
-		return Alias{}, fmt.Errorf("Unable to unmarshal response from %s: %w", url, err)
-	}
+	// The project itself only contains a limited number of aliases, so the paginated domains are searched instead
+	pager := pagination.New(h.Api, fmt.Sprintf("/v8/projects/%s/domains", projectId), query)
+	for pager.HasNext() {
+		var page struct {
+			Domains []struct {
+				Name               string `json:"name"`
+				GitBranch          string `json:"gitBranch"`
+				Redirect           string `json:"redirect"`
+				RedirectStatusCode int    `json:"redirectStatusCode"`
+				ProjectID          string `json:"projectId"`
+				CreatedAt          int64  `json:"createdAt"`
+			} `json:"domains"`
+		}
+		err := pager.Next(ctx, &page)
+		if err != nil {
+			return Alias{}, fmt.Errorf("Unable to fetch project domains from vercel: %w", err)
+		}
 
-	for _, alias := range project.Aliases {
-		if alias.Domain == domain {
-			return alias, nil
+		for _, d := range page.Domains {
+			if d.Name == domain {
+				return Alias{
+					Domain:             d.Name,
+					GitBranch:          d.GitBranch,
+					Redirect:           d.Redirect,
+					RedirectStatusCode: d.RedirectStatusCode,
+					ProjectId:          d.ProjectID,
+					CreatedAt:          d.CreatedAt,
+				}, nil
+			}
 		}
 	}
 
-	return Alias{}, fmt.Errorf("No alias with domain: %s found", domain)
+	return Alias{}, httpApi.NewNotFoundError("No alias with domain: %s found", domain)
 }
 
-func (h *Handler) Update(projectId string, alias CreateOrUpdateAlias, teamId string) error {
+func (h *Handler) Update(ctx context.Context, projectId string, alias CreateOrUpdateAlias, teamId string) error {
 	url := fmt.Sprintf("/v1/projects/%s/alias", projectId)
 	if teamId != "" {
 		url = fmt.Sprintf("%s/?teamId=%s", url, teamId)
 	}
 
-	res, err := h.Api.Request("PATCH", url, alias)
+	res, err := h.Api.RequestWithContext(ctx, "PATCH", url, alias)
 	if err != nil {
 		return fmt.Errorf("Unable to update env: %w", err)
 	}
 	defer res.Body.Close()
 	return nil
 }
-func (h *Handler) Delete(projectId, domain string, teamId string) error {
+func (h *Handler) Delete(ctx context.Context, projectId, domain string, teamId string) error {
 	url := fmt.Sprintf("/v1/projects/%s/alias?domain=%s", projectId, domain)
 	if teamId != "" {
 		url = fmt.Sprintf("%s&teamId=%s", url, teamId)
 	}
-	res, err := h.Api.Request("DELETE", url, nil)
+	res, err := h.Api.RequestWithContext(ctx, "DELETE", url, nil)
 	if err != nil {
 		return nil
 		// return fmt.Errorf("Unable to delete domain: %w", err)
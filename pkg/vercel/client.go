@@ -1,6 +1,7 @@
 package vercel
 
 import (
+	"github.com/chronark/terraform-provider-vercel/pkg/vercel/deployment"
 	"github.com/chronark/terraform-provider-vercel/pkg/vercel/env"
 	"github.com/chronark/terraform-provider-vercel/pkg/vercel/httpApi"
 	pdomain "github.com/chronark/terraform-provider-vercel/pkg/vercel/project_domain"
@@ -24,10 +25,17 @@ type Client struct {
 	Domain        *domain.Handler
 	ProjectDomain *pdomain.Handler
 	DNS           *dns.Handler
+	Deployment    *deployment.Handler
+
+	// The team used by resources that do not specify a team themselves. Empty for the personal account.
+	TeamID string
 }
 
-func New(token string) *Client {
-	api := httpApi.New(token)
+func New(token string, config httpApi.Config) (*Client, error) {
+	api, err := httpApi.New(token, config)
+	if err != nil {
+		return nil, err
+	}
 
 	return &Client{
 		Project: &project.ProjectHandler{
@@ -43,5 +51,6 @@ func New(token string) *Client {
 		Domain:        &domain.Handler{Api: api},
 		ProjectDomain: &pdomain.Handler{Api: api},
 		DNS:           &dns.Handler{Api: api},
-	}
+		Deployment:    &deployment.Handler{Api: api},
+	}, nil
 }
@@ -0,0 +1,562 @@
+package vercel_test
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/chronark/terraform-provider-vercel/pkg/vercel"
+	"github.com/chronark/terraform-provider-vercel/pkg/vercel/alias"
+	"github.com/chronark/terraform-provider-vercel/pkg/vercel/deployment"
+	"github.com/chronark/terraform-provider-vercel/pkg/vercel/dns"
+	"github.com/chronark/terraform-provider-vercel/pkg/vercel/domain"
+	"github.com/chronark/terraform-provider-vercel/pkg/vercel/env"
+	"github.com/chronark/terraform-provider-vercel/pkg/vercel/fake"
+	"github.com/chronark/terraform-provider-vercel/pkg/vercel/httpApi"
+	"github.com/chronark/terraform-provider-vercel/pkg/vercel/project"
+	pdomain "github.com/chronark/terraform-provider-vercel/pkg/vercel/project_domain"
+	"github.com/chronark/terraform-provider-vercel/pkg/vercel/secret"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestClient returns a client talking to a fresh fake api.
+func newTestClient(t *testing.T) (*vercel.Client, *fake.Server) {
+	srv := fake.NewServer("token")
+	t.Cleanup(srv.Close)
+
+	config := httpApi.DefaultConfig()
+	config.BaseURL = srv.URL
+	config.RateLimitRPS = 0
+
+	client, err := vercel.New("token", config)
+	require.NoError(t, err)
+
+	return client, srv
+}
+
+func TestClientProjectLifecycle(t *testing.T) {
+	ctx := context.Background()
+	client, _ := newTestClient(t)
+
+	create := project.CreateProject{Name: "mercury"}
+	create.GitRepository = &project.GitRepository{Type: "github", Repo: "chronark/mercury"}
+	create.BuildCommand = project.StringOrNull("echo build")
+
+	id, err := client.Project.Create(ctx, create, "")
+	require.NoError(t, err)
+
+	p, err := client.Project.Read(ctx, id, "")
+	require.NoError(t, err)
+	require.Equal(t, "mercury", p.Name)
+	require.Equal(t, "echo build", p.BuildCommand)
+	require.Equal(t, "chronark", p.Link.Org)
+	require.Equal(t, "mercury", p.Link.Repo)
+
+	err = client.Project.Update(ctx, id, project.UpdateProject{Name: "venus", Branch: "production"}, "")
+	require.NoError(t, err)
+
+	p, err = client.Project.Read(ctx, id, "")
+	require.NoError(t, err)
+	require.Equal(t, "venus", p.Name)
+	require.Equal(t, "echo build", p.BuildCommand)
+	require.Equal(t, "production", p.Link.ProductionBranch)
+
+	forkProtection := false
+	err = client.Project.Update(ctx, id, project.UpdateProject{CommandForIgnoringBuildStep: project.StringOrNull("exit 0"), GitForkProtection: &forkProtection}, "")
+	require.NoError(t, err)
+
+	directoryListing, maxAge := true, 3600
+	err = client.Project.Update(ctx, id, project.UpdateProject{
+		DirectoryListing:     &directoryListing,
+		SkewProtectionMaxAge: &maxAge,
+		ResourceConfig: &project.ResourceConfig{
+			FunctionDefaultMemoryType: project.MemoryTypePerformance,
+			FunctionDefaultTimeout:    60,
+			FunctionDefaultRegions:    []string{"fra1", "iad1"},
+		},
+	}, "")
+	require.NoError(t, err)
+
+	// An empty value clears the build command, everything else is left unchanged
+	err = client.Project.Update(ctx, id, project.UpdateProject{BuildCommand: project.StringOrNull("")}, "")
+	require.NoError(t, err)
+
+	require.NoError(t, client.Project.Unlink(ctx, id, ""))
+	p, err = client.Project.Read(ctx, id, "")
+	require.NoError(t, err)
+	require.Equal(t, "", p.Link.Type)
+	require.Equal(t, "exit 0", p.CommandForIgnoringBuildStep)
+	require.Equal(t, "", p.BuildCommand)
+	require.False(t, p.GitForkProtection)
+	require.True(t, p.DirectoryListing)
+	require.True(t, p.AutoExposeSystemEnvs)
+	require.Equal(t, 3600, p.SkewProtectionMaxAge)
+	require.Equal(t, project.ResourceConfig{
+		FunctionDefaultMemoryType: project.MemoryTypePerformance,
+		FunctionDefaultTimeout:    60,
+		FunctionDefaultRegions:    []string{"fra1", "iad1"},
+	}, p.ResourceConfig)
+
+	require.NoError(t, client.Project.Link(ctx, id, project.GitRepository{Type: "github", Repo: "chronark/venus"}, ""))
+	p, err = client.Project.Read(ctx, id, "")
+	require.NoError(t, err)
+	require.Equal(t, "venus", p.Link.Repo)
+	require.Equal(t, "main", p.Link.ProductionBranch)
+
+	_, err = client.Project.Read(ctx, id, "team_other")
+	require.True(t, httpApi.IsNotFound(err))
+
+	require.NoError(t, client.Project.Delete(ctx, id, ""))
+
+	_, err = client.Project.Read(ctx, id, "")
+	require.True(t, httpApi.IsNotFound(err))
+}
+
+func TestClientEnvAndAliasLifecycle(t *testing.T) {
+	ctx := context.Background()
+	client, _ := newTestClient(t)
+
+	projectID, err := client.Project.Create(ctx, project.CreateProject{Name: "mercury"}, "")
+	require.NoError(t, err)
+
+	envID, err := client.Env.Create(ctx, projectID, env.CreateOrUpdateEnv{
+		Type:   "plain",
+		Key:    "HELLO",
+		Value:  "world",
+		Target: []string{"production"},
+	}, "")
+	require.NoError(t, err)
+
+	err = client.Env.Update(ctx, projectID, envID, env.CreateOrUpdateEnv{
+		Type:   "plain",
+		Key:    "HELLO",
+		Value:  "mars",
+		Target: []string{"production", "preview"},
+	}, "")
+	require.NoError(t, err)
+
+	envs, err := client.Env.Read(ctx, projectID, "")
+	require.NoError(t, err)
+	require.Len(t, envs, 1)
+	require.Equal(t, "mars", envs[0].Value)
+	require.Equal(t, []string{"production", "preview"}, envs[0].Target)
+
+	require.NoError(t, client.Env.Delete(ctx, projectID, envID, ""))
+
+	_, err = client.Env.Create(ctx, projectID, env.CreateOrUpdateEnv{
+		Type:   env.TypeSensitive,
+		Key:    "TOKEN",
+		Value:  "hunter2",
+		Target: []string{"production"},
+	}, "")
+	require.NoError(t, err)
+
+	envs, err = client.Env.Read(ctx, projectID, "")
+	require.NoError(t, err)
+	require.Len(t, envs, 1)
+	require.Empty(t, envs[0].Value, "sensitive values are never returned")
+	require.NotZero(t, envs[0].UpdatedAt)
+
+	encryptedID, err := client.Env.Create(ctx, projectID, env.CreateOrUpdateEnv{
+		Type:   env.TypeEncrypted,
+		Key:    "PASSWORD",
+		Value:  "correct horse",
+		Target: []string{"production"},
+	}, "")
+	require.NoError(t, err)
+
+	encrypted, err := client.Env.Get(ctx, projectID, encryptedID, false, "")
+	require.NoError(t, err)
+	require.False(t, encrypted.HasValue())
+
+	encrypted, err = client.Env.Get(ctx, projectID, encryptedID, true, "")
+	require.NoError(t, err)
+	require.True(t, encrypted.HasValue())
+	require.Equal(t, "correct horse", encrypted.Value)
+
+	sensitive, err := client.Env.Get(ctx, projectID, envs[0].ID, true, "")
+	require.NoError(t, err)
+	require.False(t, sensitive.HasValue(), "sensitive values are never decrypted")
+
+	require.NoError(t, client.Env.Delete(ctx, projectID, encryptedID, ""))
+	_, err = client.Env.Get(ctx, projectID, encryptedID, true, "")
+	require.True(t, httpApi.IsNotFound(err))
+
+	err = client.Alias.Create(ctx, projectID, alias.CreateOrUpdateAlias{Domain: "www.chronark.com"}, "")
+	require.NoError(t, err)
+
+	a, err := client.Alias.Read(ctx, projectID, "www.chronark.com", "")
+	require.NoError(t, err)
+	require.Equal(t, projectID, a.ProjectId)
+
+	require.NoError(t, client.Alias.Delete(ctx, projectID, "www.chronark.com", ""))
+
+	_, err = client.Alias.Read(ctx, projectID, "www.chronark.com", "")
+	require.True(t, httpApi.IsNotFound(err))
+}
+
+func TestClientDomainAndDNSLifecycle(t *testing.T) {
+	ctx := context.Background()
+	client, _ := newTestClient(t)
+
+	domainID, err := client.Domain.Create(ctx, "chronark.com", "")
+	require.NoError(t, err)
+	require.NotEmpty(t, domainID)
+
+	recordID, err := client.DNS.Create(ctx, "chronark.com", dns.CreateRecord{Type: "CNAME", Name: "www", Value: "chronark.com", TTL: 60}, "")
+	require.NoError(t, err)
+
+	record, err := client.DNS.Read(ctx, "chronark.com", recordID, "")
+	require.NoError(t, err)
+	require.Equal(t, "www", record.Name)
+	require.Equal(t, 60, record.TTL)
+
+	err = client.DNS.Update(ctx, recordID, dns.UpdateRecord{Name: "www", Value: "chronark.dev", TTL: 3600}, "")
+	require.NoError(t, err)
+
+	record, err = client.DNS.Read(ctx, "chronark.com", recordID, "")
+	require.NoError(t, err)
+	require.Equal(t, "chronark.dev", record.Value)
+	require.Equal(t, 3600, record.TTL)
+
+	require.NoError(t, client.DNS.Delete(ctx, "chronark.com", recordID, ""))
+
+	_, err = client.DNS.Read(ctx, "chronark.com", recordID, "")
+	require.True(t, httpApi.IsNotFound(err))
+
+	require.NoError(t, client.Domain.Delete(ctx, "chronark.com", ""))
+
+	_, err = client.Domain.Read(ctx, "chronark.com", "")
+	require.True(t, httpApi.IsNotFound(err))
+}
+
+func TestClientVerifyProjectDomain(t *testing.T) {
+	ctx := context.Background()
+	client, _ := newTestClient(t)
+
+	projectID, err := client.Project.Create(ctx, project.CreateProject{Name: "mercury"}, "")
+	require.NoError(t, err)
+
+	created, err := client.ProjectDomain.Create(ctx, projectID, "", pdomain.CreateOrUpdateProjectDomain{Name: "www.chronark.com"})
+	require.NoError(t, err)
+	require.False(t, created.Verified)
+	require.Len(t, created.Verification, 1)
+	challenge := created.Verification[0]
+	require.Equal(t, "_vercel.chronark.com", challenge.Domain)
+
+	_, err = client.ProjectDomain.Verify(ctx, projectID, "", "www.chronark.com")
+	require.Error(t, err)
+
+	_, err = client.Domain.Create(ctx, "chronark.com", "")
+	require.NoError(t, err)
+	_, err = client.DNS.Create(ctx, "chronark.com", dns.CreateRecord{Type: challenge.Type, Name: "_vercel", Value: challenge.Value, TTL: 60}, "")
+	require.NoError(t, err)
+
+	verified, err := client.ProjectDomain.Verify(ctx, projectID, "", "www.chronark.com")
+	require.NoError(t, err)
+	require.True(t, verified.Verified)
+	require.Empty(t, verified.Verification)
+
+	redirect, statusCode := "chronark.com", 308
+	updated, err := client.ProjectDomain.Update(ctx, projectID, "", "www.chronark.com", pdomain.CreateOrUpdateProjectDomain{Name: "www.chronark.com", Redirect: &redirect, RedirectStatusCode: &statusCode})
+	require.NoError(t, err)
+	require.Equal(t, "chronark.com", updated.Redirect)
+	require.True(t, updated.Verified)
+}
+
+func TestClientVerifyDomain(t *testing.T) {
+	ctx := context.Background()
+	client, srv := newTestClient(t)
+
+	_, err := client.Domain.Create(ctx, "chronark.com", "")
+	require.NoError(t, err)
+
+	_, err = client.Domain.Verify(ctx, "chronark.com", "")
+	require.Error(t, err)
+
+	srv.SetNameservers("chronark.com", []string{"ns1.vercel-dns.com", "ns2.vercel-dns.com"})
+
+	d, err := client.Domain.Verify(ctx, "chronark.com", "")
+	require.NoError(t, err)
+	require.True(t, d.Verified)
+	require.NotZero(t, d.NsVerifiedAt)
+}
+
+func TestClientBuyAndTransferDomains(t *testing.T) {
+	ctx := context.Background()
+	client, _ := newTestClient(t)
+
+	price, err := client.Domain.Price(ctx, "chronark.com", domain.PriceTypeNew, "")
+	require.NoError(t, err)
+	require.Equal(t, domain.Price{Price: 20, Period: 1}, price)
+
+	_, err = client.Domain.Buy(ctx, domain.BuyDomain{Name: "chronark.com", ExpectedPrice: 10}, "")
+	require.Error(t, err, "the purchase must fail if the price differs")
+
+	id, err := client.Domain.Buy(ctx, domain.BuyDomain{Name: "chronark.com", ExpectedPrice: price.Price, Renew: true}, "")
+	require.NoError(t, err)
+
+	d, err := client.Domain.Read(ctx, "chronark.com", "")
+	require.NoError(t, err)
+	require.Equal(t, id, d.ID)
+	require.Equal(t, domain.ServiceTypeRegistered, d.ServiceType)
+	require.True(t, d.Renew)
+	require.NotZero(t, d.ExpiresAt)
+
+	require.NoError(t, client.Domain.SetRenew(ctx, "chronark.com", false, ""))
+	d, err = client.Domain.Read(ctx, "chronark.com", "")
+	require.NoError(t, err)
+	require.False(t, d.Renew)
+
+	_, err = client.Domain.TransferIn(ctx, "chronark.dev", "", 30, "")
+	require.Error(t, err, "transfers require an auth code")
+
+	_, err = client.Domain.TransferIn(ctx, "chronark.dev", "secret", 30, "")
+	require.NoError(t, err)
+	d, err = client.Domain.Read(ctx, "chronark.dev", "")
+	require.NoError(t, err)
+	require.NotZero(t, d.TransferStartedAt)
+}
+
+func TestClientSecretLifecycle(t *testing.T) {
+	ctx := context.Background()
+	client, _ := newTestClient(t)
+
+	secretID, err := client.Secret.Create(ctx, secret.CreateSecret{Name: "my-secret", Value: "hunter2"}, "team_fake")
+	require.NoError(t, err)
+
+	s, err := client.Secret.Read(ctx, secretID, "team_fake")
+	require.NoError(t, err)
+	require.Equal(t, "my-secret", s.Name)
+	require.Equal(t, "team_fake", s.TeamID)
+
+	require.NoError(t, client.Secret.Update(ctx, "my-secret", "renamed", "team_fake"))
+	require.NoError(t, client.Secret.Delete(ctx, "renamed", "team_fake"))
+
+	_, err = client.Secret.Read(ctx, secretID, "team_fake")
+	require.True(t, httpApi.IsNotFound(err))
+}
+
+func TestClientDeploymentUploadsMissingFiles(t *testing.T) {
+	ctx := context.Background()
+	client, _ := newTestClient(t)
+
+	dir, err := ioutil.TempDir("", "deployment")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "index.html"), []byte("<h1>hello</h1>"), 0644))
+
+	files, err := deployment.ReadDir(dir)
+	require.NoError(t, err)
+
+	projectID, err := client.Project.Create(ctx, project.CreateProject{Name: "mercury"}, "")
+	require.NoError(t, err)
+
+	created, err := client.Deployment.Create(ctx, deployment.CreateDeployment{Name: "mercury", Project: projectID}, files, "")
+	require.NoError(t, err)
+	require.Equal(t, deployment.StateQueued, created.ReadyState)
+	require.Equal(t, projectID, created.ProjectID)
+
+	d, err := client.Deployment.Read(ctx, created.ID, "")
+	require.NoError(t, err)
+	require.Equal(t, deployment.StateReady, d.ReadyState)
+	require.Equal(t, created.URL, d.URL)
+
+	require.NoError(t, client.Deployment.Delete(ctx, created.ID, ""))
+
+	_, err = client.Deployment.Read(ctx, created.ID, "")
+	require.True(t, httpApi.IsNotFound(err))
+}
+
+func TestClientListDeployments(t *testing.T) {
+	ctx := context.Background()
+	client, _ := newTestClient(t)
+
+	create := func(name, target, branch string) deployment.Deployment {
+		d, err := client.Deployment.Create(ctx, deployment.CreateDeployment{
+			Name:   name,
+			Target: target,
+			Meta:   map[string]string{"githubCommitRef": branch},
+		}, nil, "")
+		require.NoError(t, err)
+		return d
+	}
+
+	first := create("mercury", "production", "main")
+	preview := create("mercury", "", "feature")
+	latest := create("mercury", "production", "main")
+	create("venus", "production", "main")
+
+	all, err := client.Deployment.List(ctx, deployment.ListDeployments{ProjectID: first.ProjectID}, "")
+	require.NoError(t, err)
+	require.Len(t, all, 3)
+	require.Equal(t, latest.ID, all[0].ID)
+
+	production, err := client.Deployment.List(ctx, deployment.ListDeployments{ProjectID: first.ProjectID, Target: "production", Limit: 1}, "")
+	require.NoError(t, err)
+	require.Len(t, production, 1)
+	require.Equal(t, latest.ID, production[0].ID)
+
+	feature, err := client.Deployment.List(ctx, deployment.ListDeployments{ProjectID: first.ProjectID, Branch: "feature"}, "")
+	require.NoError(t, err)
+	require.Len(t, feature, 1)
+	require.Equal(t, preview.ID, feature[0].ID)
+	require.Equal(t, "feature", feature[0].Branch())
+}
+
+func TestClientListProjectsFetchesAllPages(t *testing.T) {
+	ctx := context.Background()
+	client, _ := newTestClient(t)
+
+	for i := 0; i < 105; i++ {
+		_, err := client.Project.Create(ctx, project.CreateProject{Name: fmt.Sprintf("project-%03d", i)}, "")
+		require.NoError(t, err)
+	}
+	_, err := client.Project.Create(ctx, project.CreateProject{Name: "other"}, "team_other")
+	require.NoError(t, err)
+
+	projects, err := client.Project.List(ctx, "", "")
+	require.NoError(t, err)
+	require.Len(t, projects, 105)
+
+	seen := map[string]bool{}
+	for _, p := range projects {
+		seen[p.ID] = true
+	}
+	require.Len(t, seen, 105)
+
+	projects, err = client.Project.List(ctx, "-10", "")
+	require.NoError(t, err)
+	require.Len(t, projects, 5)
+}
+
+func TestClientListsFollowPagination(t *testing.T) {
+	ctx := context.Background()
+	client, _ := newTestClient(t)
+
+	_, err := client.Domain.Create(ctx, "chronark.com", "")
+	require.NoError(t, err)
+
+	var lastRecordID string
+	for i := 0; i < 120; i++ {
+		lastRecordID, err = client.DNS.Create(ctx, "chronark.com", dns.CreateRecord{Type: "A", Name: fmt.Sprintf("host-%d", i), Value: "127.0.0.1", TTL: 60}, "")
+		require.NoError(t, err)
+	}
+
+	records, err := client.DNS.List(ctx, "chronark.com", "")
+	require.NoError(t, err)
+	require.Len(t, records, 120)
+
+	// The record is only part of the second page
+	record, err := client.DNS.Read(ctx, "chronark.com", lastRecordID, "")
+	require.NoError(t, err)
+	require.Equal(t, "host-119", record.Name)
+
+	projectID, err := client.Project.Create(ctx, project.CreateProject{Name: "mercury"}, "")
+	require.NoError(t, err)
+	for i := 0; i < 105; i++ {
+		err = client.Alias.Create(ctx, projectID, alias.CreateOrUpdateAlias{Domain: fmt.Sprintf("%d.chronark.com", i)}, "")
+		require.NoError(t, err)
+	}
+
+	a, err := client.Alias.Read(ctx, projectID, "104.chronark.com", "")
+	require.NoError(t, err)
+	require.Equal(t, projectID, a.ProjectId)
+
+}
+
+func TestClientTypedDNSRecords(t *testing.T) {
+	ctx := context.Background()
+	client, _ := newTestClient(t)
+
+	_, err := client.Domain.Create(ctx, "chronark.com", "")
+	require.NoError(t, err)
+
+	priority := 0
+	mxID, err := client.DNS.Create(ctx, "chronark.com", dns.CreateRecord{Type: dns.TypeMX, Name: "", Value: "mx1.improvmx.com", TTL: 60, MXPriority: &priority, Comment: "mail"}, "")
+	require.NoError(t, err)
+
+	mx, err := client.DNS.Read(ctx, "chronark.com", mxID, "")
+	require.NoError(t, err)
+	require.Equal(t, 0, mx.MXPriority)
+	require.Equal(t, "mail", mx.Comment)
+
+	srv := &dns.SRV{Priority: 10, Weight: 5, Port: 5060, Target: "sip.chronark.com"}
+	srvID, err := client.DNS.Create(ctx, "chronark.com", dns.CreateRecord{Type: dns.TypeSRV, Name: "_sip._tcp", TTL: 60, SRV: srv}, "")
+	require.NoError(t, err)
+
+	record, err := client.DNS.Read(ctx, "chronark.com", srvID, "")
+	require.NoError(t, err)
+	parsed, err := record.SRV()
+	require.NoError(t, err)
+	require.Equal(t, *srv, parsed)
+
+	_, err = client.DNS.Create(ctx, "chronark.com", dns.CreateRecord{Type: dns.TypeMX, Value: "mx1.improvmx.com", TTL: 60}, "")
+	require.Error(t, err)
+}
+
+func TestClientApplyZone(t *testing.T) {
+	ctx := context.Background()
+	client, _ := newTestClient(t)
+
+	_, err := client.Domain.Create(ctx, "chronark.com", "")
+	require.NoError(t, err)
+
+	_, err = client.DNS.Create(ctx, "chronark.com", dns.CreateRecord{Type: dns.TypeA, Name: "manual", Value: "1.1.1.1", TTL: 60}, "")
+	require.NoError(t, err)
+
+	desired, err := dns.ParseZone(`
+@    A     76.76.21.21
+www  CNAME cname.vercel-dns.com.
+`, "chronark.com")
+	require.NoError(t, err)
+
+	existing, err := client.DNS.List(ctx, "chronark.com", "")
+	require.NoError(t, err)
+	require.NoError(t, client.DNS.Apply(ctx, "chronark.com", dns.Diff(desired, existing), ""))
+
+	records, err := client.DNS.List(ctx, "chronark.com", "")
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+	require.True(t, dns.Diff(desired, records).Empty())
+}
+
+func TestClientApplyEnvInBatches(t *testing.T) {
+	ctx := context.Background()
+	client, _ := newTestClient(t)
+
+	projectID, err := client.Project.Create(ctx, project.CreateProject{Name: "mercury"}, "")
+	require.NoError(t, err)
+
+	desired := []env.CreateOrUpdateEnv{}
+	for i := 0; i < 80; i++ {
+		desired = append(desired, env.CreateOrUpdateEnv{
+			Type:   env.TypePlain,
+			Key:    fmt.Sprintf("KEY_%d", i),
+			Value:  "value",
+			Target: []string{"production"},
+		})
+	}
+	require.NoError(t, client.Env.Apply(ctx, projectID, env.Diff(desired, nil), ""))
+
+	existing, err := client.Env.Read(ctx, projectID, "")
+	require.NoError(t, err)
+	require.Len(t, existing, 80)
+	require.True(t, env.Diff(desired, existing).Empty())
+
+	desired[0].Value = "changed"
+	changes := env.Diff(desired[:60], existing)
+	require.Len(t, changes.Update, 1)
+	require.Len(t, changes.Delete, 20)
+	require.NoError(t, client.Env.Apply(ctx, projectID, changes, ""))
+
+	existing, err = client.Env.Read(ctx, projectID, "")
+	require.NoError(t, err)
+	require.True(t, env.Diff(desired[:60], existing).Empty())
+
+	_, err = client.Env.CreateMany(ctx, projectID, desired[:1], "")
+	require.Error(t, err, "duplicate keys are reported")
+}
@@ -0,0 +1,228 @@
+package deployment
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/chronark/terraform-provider-vercel/pkg/vercel/httpApi"
+	"github.com/chronark/terraform-provider-vercel/pkg/vercel/pagination"
+)
+
+type Handler struct {
+	Api httpApi.API
+}
+
+// UploadFile uploads the content of a single file. Vercel deduplicates files by their digest, so uploading
+// a file that already exists is cheap.
+func (h *Handler) UploadFile(ctx context.Context, content []byte, teamId string) error {
+	url := "/v2/files"
+	if teamId != "" {
+		url = fmt.Sprintf("%s?teamId=%s", url, teamId)
+	}
+
+	header := http.Header{}
+	header.Set("Content-Type", "application/octet-stream")
+	header.Set("Content-Length", strconv.Itoa(len(content)))
+	header.Set("x-vercel-digest", Sha1(content))
+
+	res, err := h.Api.RequestRaw(ctx, http.MethodPost, url, content, header)
+	if err != nil {
+		return fmt.Errorf("Unable to upload file: %w", err)
+	}
+	defer res.Body.Close()
+	return nil
+}
+
+// Create creates a deployment from the given files. Files vercel does not know yet are uploaded
+// before the deployment is created again.
+func (h *Handler) Create(ctx context.Context, deployment CreateDeployment, files []LocalFile, teamId string) (Deployment, error) {
+	deployment.Files = make([]File, len(files))
+	for i, f := range files {
+		deployment.Files[i] = f.File
+	}
+
+	created, err := h.create(ctx, deployment, teamId)
+
+	var vercelError *httpApi.VercelError
+	if errors.As(err, &vercelError) && vercelError.Code == "missing_files" {
+		err = h.uploadMissing(ctx, files, vercelError.Missing, teamId)
+		if err != nil {
+			return Deployment{}, err
+		}
+		created, err = h.create(ctx, deployment, teamId)
+	}
+	if err != nil {
+		return Deployment{}, err
+	}
+
+	return created, nil
+}
+
+func (h *Handler) create(ctx context.Context, deployment CreateDeployment, teamId string) (Deployment, error) {
+	url := "/v13/deployments"
+	if teamId != "" {
+		url = fmt.Sprintf("%s?teamId=%s", url, teamId)
+	}
+
+	res, err := h.Api.RequestWithContext(ctx, http.MethodPost, url, deployment)
+	if err != nil {
+		return Deployment{}, fmt.Errorf("Unable to create deployment: %w", err)
+	}
+	defer res.Body.Close()
+
+	var created Deployment
+	err = json.NewDecoder(res.Body).Decode(&created)
+	if err != nil {
+		return Deployment{}, fmt.Errorf("Unable to unmarshal deployment response: %w", err)
+	}
+	return created, nil
+}
+
+func (h *Handler) uploadMissing(ctx context.Context, files []LocalFile, missing []string, teamId string) error {
+	paths := map[string]string{}
+	for _, f := range files {
+		paths[f.Sha] = f.Path
+	}
+
+	for _, sha := range missing {
+		path, ok := paths[sha]
+		if !ok {
+			return fmt.Errorf("vercel asked for file %s which is not part of the deployment", sha)
+		}
+		content, err := ioutil.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("Unable to read %s: %w", path, err)
+		}
+		err = h.UploadFile(ctx, content, teamId)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Read returns a deployment by its id or url
+func (h *Handler) Read(ctx context.Context, idOrUrl string, teamId string) (deployment Deployment, err error) {
+	url := fmt.Sprintf("/v13/deployments/%s", idOrUrl)
+	if teamId != "" {
+		url = fmt.Sprintf("%s?teamId=%s", url, teamId)
+	}
+
+	res, err := h.Api.RequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return Deployment{}, fmt.Errorf("Unable to fetch deployment from vercel: %w", err)
+	}
+	defer res.Body.Close()
+
+	err = json.NewDecoder(res.Body).Decode(&deployment)
+	if err != nil {
+		return Deployment{}, fmt.Errorf("Unable to unmarshal deployment response: %w", err)
+	}
+	return deployment, nil
+}
+
+// The list endpoint returns a shorter representation of a deployment than the other endpoints.
+type listedDeployment struct {
+	UID        string            `json:"uid"`
+	Name       string            `json:"name"`
+	URL        string            `json:"url"`
+	ProjectID  string            `json:"projectId"`
+	Created    int64             `json:"created"`
+	State      string            `json:"state"`
+	ReadyState string            `json:"readyState"`
+	Target     string            `json:"target"`
+	Meta       map[string]string `json:"meta"`
+	Creator    struct {
+		UID      string `json:"uid"`
+		Email    string `json:"email"`
+		Username string `json:"username"`
+	} `json:"creator"`
+}
+
+func (l listedDeployment) deployment(projectID string) Deployment {
+	d := Deployment{
+		ID:         l.UID,
+		Name:       l.Name,
+		URL:        l.URL,
+		ProjectID:  projectID,
+		Target:     l.Target,
+		ReadyState: l.ReadyState,
+		Meta:       l.Meta,
+		CreatedAt:  l.Created,
+	}
+	if l.ProjectID != "" {
+		d.ProjectID = l.ProjectID
+	}
+	if d.ReadyState == "" {
+		d.ReadyState = l.State
+	}
+	d.Creator.UID = l.Creator.UID
+	d.Creator.Email = l.Creator.Email
+	d.Creator.Username = l.Creator.Username
+	return d
+}
+
+// List returns the deployments matching the filter, newest first
+func (h *Handler) List(ctx context.Context, filter ListDeployments, teamId string) ([]Deployment, error) {
+	query := url.Values{}
+	if filter.ProjectID != "" {
+		query.Set("projectId", filter.ProjectID)
+	}
+	if filter.Target != "" {
+		query.Set("target", filter.Target)
+	}
+	if filter.State != "" {
+		query.Set("state", filter.State)
+	}
+	// Without a branch filter a small limit saves fetching a full page
+	if filter.Limit > 0 && filter.Limit < pagination.PageSize && filter.Branch == "" {
+		query.Set("limit", strconv.Itoa(filter.Limit))
+	}
+	if teamId != "" {
+		query.Set("teamId", teamId)
+	}
+
+	deployments := []Deployment{}
+	pager := pagination.New(h.Api, "/v6/deployments", query)
+	for pager.HasNext() {
+		var page struct {
+			Deployments []listedDeployment `json:"deployments"`
+		}
+		err := pager.Next(ctx, &page)
+		if err != nil {
+			return nil, fmt.Errorf("Unable to fetch deployments from vercel: %w", err)
+		}
+
+		for _, l := range page.Deployments {
+			d := l.deployment(filter.ProjectID)
+			if filter.Branch != "" && d.Branch() != filter.Branch {
+				continue
+			}
+			deployments = append(deployments, d)
+			if filter.Limit > 0 && len(deployments) == filter.Limit {
+				return deployments, nil
+			}
+		}
+	}
+	return deployments, nil
+}
+
+func (h *Handler) Delete(ctx context.Context, id string, teamId string) error {
+	url := fmt.Sprintf("/v13/deployments/%s", id)
+	if teamId != "" {
+		url = fmt.Sprintf("%s?teamId=%s", url, teamId)
+	}
+
+	res, err := h.Api.RequestWithContext(ctx, http.MethodDelete, url, nil)
+	if err != nil {
+		return fmt.Errorf("Unable to delete deployment: %w", err)
+	}
+	defer res.Body.Close()
+	return nil
+}
@@ -0,0 +1,101 @@
+package deployment
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Directories that are never part of a deployment.
+var ignoredDirs = map[string]bool{
+	".git":         true,
+	".vercel":      true,
+	"node_modules": true,
+}
+
+// LocalFile is a file on disk that belongs to a deployment.
+type LocalFile struct {
+	// The absolute path used to read the file for an upload.
+	Path string
+
+	File
+}
+
+// ReadDir walks the directory and returns every file in it with its path relative to the directory.
+// The result is sorted by that path.
+func ReadDir(dir string) ([]LocalFile, error) {
+	root, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := os.Stat(root)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read deployment directory: %w", err)
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("%s is not a directory", dir)
+	}
+
+	var files []LocalFile
+	err = filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			if path != root && ignoredDirs[info.Name()] {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+
+		content, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+
+		files = append(files, LocalFile{
+			Path: path,
+			File: File{
+				File: filepath.ToSlash(rel),
+				Sha:  Sha1(content),
+				Size: int64(len(content)),
+			},
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to read deployment directory: %w", err)
+	}
+
+	sort.Slice(files, func(i, j int) bool { return files[i].File.File < files[j].File.File })
+	return files, nil
+}
+
+// Sha1 returns the digest vercel uses to identify a file.
+func Sha1(content []byte) string {
+	sum := sha1.Sum(content)
+	return hex.EncodeToString(sum[:])
+}
+
+// Hash returns a digest over the names and contents of all files. It changes whenever a file is added,
+// removed, renamed or modified.
+func Hash(files []LocalFile) string {
+	var b strings.Builder
+	for _, f := range files {
+		fmt.Fprintf(&b, "%s:%s\n", f.File.File, f.Sha)
+	}
+	return Sha1([]byte(b.String()))
+}
@@ -0,0 +1,66 @@
+package deployment
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func writeFile(t *testing.T, dir, name, content string) {
+	path := filepath.Join(dir, name)
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+	require.NoError(t, ioutil.WriteFile(path, []byte(content), 0644))
+}
+
+func TestReadDir(t *testing.T) {
+	dir, err := ioutil.TempDir("", "deployment")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	writeFile(t, dir, "index.html", "<h1>hello</h1>")
+	writeFile(t, dir, "assets/app.js", "console.log(1)")
+	writeFile(t, dir, ".git/HEAD", "ref: refs/heads/main")
+	writeFile(t, dir, "node_modules/left-pad/index.js", "")
+
+	files, err := ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, files, 2)
+
+	require.Equal(t, "assets/app.js", files[0].File.File)
+	require.Equal(t, "index.html", files[1].File.File)
+	require.Equal(t, Sha1([]byte("<h1>hello</h1>")), files[1].Sha)
+	require.Equal(t, int64(14), files[1].Size)
+	require.Equal(t, filepath.Join(dir, "index.html"), files[1].Path)
+}
+
+func TestReadDirMissing(t *testing.T) {
+	_, err := ReadDir(filepath.Join(os.TempDir(), "does-not-exist-at-all"))
+	require.Error(t, err)
+}
+
+func TestHash(t *testing.T) {
+	dir, err := ioutil.TempDir("", "deployment")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	writeFile(t, dir, "index.html", "hello")
+
+	hash := func() string {
+		files, err := ReadDir(dir)
+		require.NoError(t, err)
+		return Hash(files)
+	}
+
+	initial := hash()
+	require.Equal(t, initial, hash())
+
+	writeFile(t, dir, "index.html", "world")
+	changed := hash()
+	require.NotEqual(t, initial, changed)
+
+	require.NoError(t, os.Rename(filepath.Join(dir, "index.html"), filepath.Join(dir, "home.html")))
+	require.NotEqual(t, changed, hash())
+}
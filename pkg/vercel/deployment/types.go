@@ -0,0 +1,68 @@
+package deployment
+
+// File references an uploaded file by its sha1 digest.
+type File struct {
+	File string `json:"file"`
+	Sha  string `json:"sha"`
+	Size int64  `json:"size"`
+}
+
+type CreateDeployment struct {
+	Name    string            `json:"name"`
+	Project string            `json:"project,omitempty"`
+	Target  string            `json:"target,omitempty"`
+	Meta    map[string]string `json:"meta,omitempty"`
+	Files   []File            `json:"files"`
+}
+
+type Deployment struct {
+	ID           string            `json:"id"`
+	URL          string            `json:"url"`
+	Name         string            `json:"name"`
+	ProjectID    string            `json:"projectId"`
+	OwnerID      string            `json:"ownerId"`
+	Target       string            `json:"target"`
+	ReadyState   string            `json:"readyState"`
+	ErrorMessage string            `json:"errorMessage"`
+	Meta         map[string]string `json:"meta"`
+	Alias        []string          `json:"alias"`
+	CreatedAt    int64             `json:"createdAt"`
+	Ready        int64             `json:"ready"`
+	Creator      struct {
+		UID      string `json:"uid"`
+		Email    string `json:"email"`
+		Username string `json:"username"`
+	} `json:"creator"`
+}
+
+// Branch returns the git branch the deployment was built from, or an empty string for deployments that
+// were not triggered by a git integration.
+func (d Deployment) Branch() string {
+	for _, key := range []string{"githubCommitRef", "gitlabCommitRef", "bitbucketCommitRef"} {
+		if ref := d.Meta[key]; ref != "" {
+			return ref
+		}
+	}
+	return ""
+}
+
+// ListDeployments filters the deployments returned by `Handler.List`. Empty fields match everything.
+type ListDeployments struct {
+	ProjectID string
+	Target    string
+	State     string
+	// Vercel can not filter by branch, these are filtered after they were fetched.
+	Branch string
+	// The maximum number of deployments to return, 0 uses the default of the api.
+	Limit int
+}
+
+// The states a deployment goes through, READY, ERROR and CANCELED are final.
+const (
+	StateQueued       = "QUEUED"
+	StateInitializing = "INITIALIZING"
+	StateBuilding     = "BUILDING"
+	StateReady        = "READY"
+	StateError        = "ERROR"
+	StateCanceled     = "CANCELED"
+)
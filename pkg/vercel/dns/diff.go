@@ -0,0 +1,197 @@
+package dns
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// CreatorSystem is the creator of records vercel manages automatically.
+const CreatorSystem = "system"
+
+// RecordUpdate is an existing record that has to be changed.
+type RecordUpdate struct {
+	ID     string
+	Record UpdateRecord
+}
+
+// Changes turn the existing records of a domain into the desired ones.
+type Changes struct {
+	Create []CreateRecord
+	Update []RecordUpdate
+	Delete []Record
+}
+
+// Empty reports whether the records are already in the desired state.
+func (c Changes) Empty() bool {
+	return len(c.Create) == 0 && len(c.Update) == 0 && len(c.Delete) == 0
+}
+
+// Diff compares the desired records of a domain with the existing ones.
+// Records are matched by type, name and value first, the remaining records with the same type and
+// name are updated in place. Every other existing record is deleted, except system records which
+// are never changed but still satisfy a desired record with the same content.
+func Diff(desired []CreateRecord, existing []Record) Changes {
+	changes := Changes{}
+	matched := make([]bool, len(existing))
+
+	unmatched := []CreateRecord{}
+	for _, want := range desired {
+		found := false
+		for i, have := range existing {
+			if matched[i] || have.Type != want.Type || !strings.EqualFold(have.Name, want.Name) || have.content() != want.content() {
+				continue
+			}
+			matched[i] = true
+			found = true
+			if have.Creator != CreatorSystem && !have.settingsMatch(want) {
+				changes.Update = append(changes.Update, RecordUpdate{ID: have.Id, Record: want.update()})
+			}
+			break
+		}
+		if !found {
+			unmatched = append(unmatched, want)
+		}
+	}
+
+	for _, want := range unmatched {
+		found := false
+		for i, have := range existing {
+			if matched[i] || have.Creator == CreatorSystem || have.Type != want.Type || !strings.EqualFold(have.Name, want.Name) {
+				continue
+			}
+			matched[i] = true
+			found = true
+			changes.Update = append(changes.Update, RecordUpdate{ID: have.Id, Record: want.update()})
+			break
+		}
+		if !found {
+			changes.Create = append(changes.Create, want)
+		}
+	}
+
+	for i, have := range existing {
+		if !matched[i] && have.Creator != CreatorSystem {
+			changes.Delete = append(changes.Delete, have)
+		}
+	}
+	return changes
+}
+
+// Remaining returns the records of a domain after the changes are applied to the existing records.
+// New records have no id and creator until they are created.
+func (c Changes) Remaining(existing []Record) []Record {
+	deleted := map[string]bool{}
+	for _, record := range c.Delete {
+		deleted[record.Id] = true
+	}
+	updates := map[string]UpdateRecord{}
+	for _, update := range c.Update {
+		updates[update.ID] = update.Record
+	}
+
+	remaining := []Record{}
+	for _, record := range existing {
+		if deleted[record.Id] {
+			continue
+		}
+		if update, ok := updates[record.Id]; ok {
+			record = record.updated(update)
+		}
+		remaining = append(remaining, record)
+	}
+	for _, create := range c.Create {
+		remaining = append(remaining, Record{Type: create.Type}.updated(create.update()))
+	}
+	return remaining
+}
+
+// updated returns the record the way vercel stores it after the update, SRV records keep their priority separately.
+func (r Record) updated(update UpdateRecord) Record {
+	r.Name = update.Name
+	r.Value = update.Value
+	r.TTL = update.TTL
+	r.Comment = update.Comment
+	if update.MXPriority != nil {
+		r.MXPriority = *update.MXPriority
+	}
+	if update.SRV != nil {
+		r.Priority = update.SRV.Priority
+		r.Value = fmt.Sprintf("%d %d %s", update.SRV.Weight, update.SRV.Port, update.SRV.Target)
+	}
+	return r
+}
+
+// content is the part of a record that identifies it among the records of the same type and name.
+func (r CreateRecord) content() string {
+	if r.SRV != nil {
+		return fmt.Sprintf("%d %d %d %s", r.SRV.Priority, r.SRV.Weight, r.SRV.Port, normalizeValue(r.Type, r.SRV.Target))
+	}
+	return normalizeValue(r.Type, r.Value)
+}
+
+func (r Record) content() string {
+	if r.Type == TypeSRV {
+		srv, err := r.SRV()
+		if err != nil {
+			return r.Value
+		}
+		return CreateRecord{Type: r.Type, SRV: &srv}.content()
+	}
+	return normalizeValue(r.Type, r.Value)
+}
+
+// settingsMatch compares everything that is not part of the content.
+func (r Record) settingsMatch(want CreateRecord) bool {
+	if r.TTL != want.TTL || r.Comment != want.Comment {
+		return false
+	}
+	if want.MXPriority != nil && r.MXPriority != *want.MXPriority {
+		return false
+	}
+	return true
+}
+
+func (r CreateRecord) update() UpdateRecord {
+	return UpdateRecord{
+		Name:       r.Name,
+		Value:      r.Value,
+		TTL:        r.TTL,
+		MXPriority: r.MXPriority,
+		SRV:        r.SRV,
+		Comment:    r.Comment,
+	}
+}
+
+// Hostnames are case insensitive and may be written with or without the trailing dot.
+func normalizeValue(recordType, value string) string {
+	switch recordType {
+	case TypeALIAS, TypeCNAME, TypeMX, TypeNS, TypeSRV:
+		return strings.ToLower(strings.TrimSuffix(value, "."))
+	}
+	return value
+}
+
+// Apply makes the changes to the records of domain. Records are deleted first, so a record can be
+// replaced by a conflicting one, e.g. a CNAME by an A record with the same name.
+func (h *Handler) Apply(ctx context.Context, domain string, changes Changes, teamId string) error {
+	for _, record := range changes.Delete {
+		err := h.Delete(ctx, domain, record.Id, teamId)
+		if err != nil {
+			return err
+		}
+	}
+	for _, update := range changes.Update {
+		err := h.Update(ctx, update.ID, update.Record, teamId)
+		if err != nil {
+			return err
+		}
+	}
+	for _, record := range changes.Create {
+		_, err := h.Create(ctx, domain, record, teamId)
+		if err != nil {
+			return fmt.Errorf("Unable to create %s record %q: %w", record.Type, record.Name, err)
+		}
+	}
+	return nil
+}
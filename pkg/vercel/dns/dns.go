@@ -1,11 +1,14 @@
 package dns
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
 
 	"github.com/chronark/terraform-provider-vercel/pkg/vercel/httpApi"
+	"github.com/chronark/terraform-provider-vercel/pkg/vercel/pagination"
 )
 
 type CreateRecord struct {
@@ -16,11 +19,49 @@ type CreateRecord struct {
 	// A subdomain name or an empty string for the root domain.
 	Name string `json:"name"`
 
-	// The record value.
-	Value string `json:"value"`
+	// The record value. SRV records use the SRV field instead.
+	Value string `json:"value,omitempty"`
 
 	// The TTL value. Must be a number between 60 and 2147483647. Default value is 60.
 	TTL int `json:"ttl"`
+
+	// The priority of an MX record, required for MX records.
+	MXPriority *int `json:"mxPriority,omitempty"`
+
+	// The target of an SRV record, required for SRV records.
+	SRV *SRV `json:"srv,omitempty"`
+
+	// A note about the record, at most 500 characters.
+	Comment string `json:"comment,omitempty"`
+}
+
+// UpdateRecord has all the values that can be changed without recreating a record
+type UpdateRecord struct {
+	// A subdomain name or an empty string for the root domain.
+	Name string `json:"name"`
+
+	// The record value. SRV records use the SRV field instead.
+	Value string `json:"value,omitempty"`
+
+	// The TTL value. Must be a number between 60 and 2147483647.
+	TTL int `json:"ttl"`
+
+	// The priority of an MX record.
+	MXPriority *int `json:"mxPriority,omitempty"`
+
+	// The target of an SRV record.
+	SRV *SRV `json:"srv,omitempty"`
+
+	// A note about the record, an empty string removes it.
+	Comment string `json:"comment"`
+}
+
+// SRV describes the service an SRV record points to.
+type SRV struct {
+	Priority int    `json:"priority"`
+	Weight   int    `json:"weight"`
+	Port     int    `json:"port"`
+	Target   string `json:"target"`
 }
 
 type Record struct {
@@ -37,6 +78,18 @@ type Record struct {
 	// The record value.
 	Value string `json:"value"`
 
+	// The TTL value of the record.
+	TTL int `json:"ttl"`
+
+	// The priority of an MX record.
+	MXPriority int `json:"mxPriority"`
+
+	// The priority of an SRV record, the rest of the SRV data is part of the value.
+	Priority int `json:"priority"`
+
+	// A note about the record.
+	Comment string `json:"comment"`
+
 	// The ID of the user who created the record or system if the record is an automatic record.
 	Creator string `json:"creator"`
 
@@ -57,12 +110,12 @@ type Handler struct {
 	Api httpApi.API
 }
 
-func (h *Handler) Create(domain string, record CreateRecord, teamId string) (string, error) {
+func (h *Handler) Create(ctx context.Context, domain string, record CreateRecord, teamId string) (string, error) {
 	url := fmt.Sprintf("/v2/domains/%s/records", domain)
 	if teamId != "" {
 		url = fmt.Sprintf("%s/?teamId=%s", url, teamId)
 	}
-	res, err := h.Api.Request(http.MethodPost, url, record)
+	res, err := h.Api.RequestWithContext(ctx, http.MethodPost, url, record)
 	if err != nil {
 		return "", err
 	}
@@ -82,41 +135,63 @@ func (h *Handler) Create(domain string, record CreateRecord, teamId string) (str
 	return createResponse.UID, nil
 }
 
-func (h *Handler) Read(domain, recordId, teamId string) (Record, error) {
-	url := fmt.Sprintf("/v2/domains/%s/records?limit=1000", domain)
+// List returns all records of a domain
+func (h *Handler) List(ctx context.Context, domain string, teamId string) ([]Record, error) {
+	query := url.Values{}
 	if teamId != "" {
-		url = fmt.Sprintf("%s&teamId=%s", url, teamId)
-	}
-	res, err := h.Api.Request("GET", url, nil)
-	if err != nil {
-		return Record{}, fmt.Errorf("Unable to fetch dns records: %w", err)
+		query.Set("teamId", teamId)
 	}
-	defer res.Body.Close()
 
-	type Response struct {
-		Records []Record `json:"records"`
+	records := []Record{}
+	pager := pagination.New(h.Api, fmt.Sprintf("/v4/domains/%s/records", domain), query)
+	for pager.HasNext() {
+		var page struct {
+			Records []Record `json:"records"`
+		}
+		err := pager.Next(ctx, &page)
+		if err != nil {
+			return nil, fmt.Errorf("Unable to fetch dns records: %w", err)
+		}
+		records = append(records, page.Records...)
 	}
-	var response Response
-	err = json.NewDecoder(res.Body).Decode(&response)
+	return records, nil
+}
+
+// Read returns a single record. Vercel has no endpoint for this, so all records of the domain are searched.
+func (h *Handler) Read(ctx context.Context, domain, recordId, teamId string) (Record, error) {
+	records, err := h.List(ctx, domain, teamId)
 	if err != nil {
 		return Record{}, err
 	}
 
-	for _, record := range response.Records {
+	for _, record := range records {
 		if record.Id == recordId {
 			return record, nil
 		}
 	}
-	return Record{}, fmt.Errorf("Record with id %s was not found", recordId)
+	return Record{}, httpApi.NewNotFoundError("Record with id %s was not found", recordId)
+
+}
 
+func (h *Handler) Update(ctx context.Context, recordId string, record UpdateRecord, teamId string) error {
+	url := fmt.Sprintf("/v1/domains/records/%s", recordId)
+	if teamId != "" {
+		url = fmt.Sprintf("%s?teamId=%s", url, teamId)
+	}
+	res, err := h.Api.RequestWithContext(ctx, http.MethodPatch, url, record)
+	if err != nil {
+		return fmt.Errorf("Unable to update dns record: %w", err)
+	}
+	defer res.Body.Close()
+	return nil
 }
 
-func (h *Handler) Delete(domain, recordId string, teamId string) error {
+func (h *Handler) Delete(ctx context.Context, domain, recordId string, teamId string) error {
 	url := fmt.Sprintf("/v2/domains/%s/records/%s", domain, recordId)
 	if teamId != "" {
 		url = fmt.Sprintf("%s/?teamId=%s", url, teamId)
 	}
-	res, err := h.Api.Request("DELETE", url, nil)
+	res, err := h.Api.RequestWithContext(ctx, "DELETE", url, nil)
 	if err != nil {
 		return fmt.Errorf("Unable to delete dns record: %w", err)
 	}
@@ -0,0 +1,132 @@
+package dns
+
+import (
+	"fmt"
+	"net"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// The record types vercel supports.
+const (
+	TypeA     = "A"
+	TypeAAAA  = "AAAA"
+	TypeALIAS = "ALIAS"
+	TypeCAA   = "CAA"
+	TypeCNAME = "CNAME"
+	TypeHTTPS = "HTTPS"
+	TypeMX    = "MX"
+	TypeNS    = "NS"
+	TypeSRV   = "SRV"
+	TypeTXT   = "TXT"
+)
+
+// Types lists all record types in the order they appear in error messages.
+var Types = []string{TypeA, TypeAAAA, TypeALIAS, TypeCAA, TypeCNAME, TypeHTTPS, TypeMX, TypeNS, TypeSRV, TypeTXT}
+
+// MaxCommentLength is the longest comment vercel accepts for a record.
+const MaxCommentLength = 500
+
+var (
+	hostname = regexp.MustCompile(`^(\*\.)?([a-zA-Z0-9_]([a-zA-Z0-9_-]{0,61}[a-zA-Z0-9])?\.)*[a-zA-Z0-9_]([a-zA-Z0-9_-]{0,61}[a-zA-Z0-9])?\.?$`)
+
+	// flags tag "value", e.g. 0 issue "letsencrypt.org"
+	caa = regexp.MustCompile(`^\d{1,3} (issue|issuewild|iodef) "[^"]*"$`)
+
+	// priority target params..., e.g. 1 . alpn="h3,h2"
+	https = regexp.MustCompile(`^\d{1,5} \S+( \S+)*$`)
+)
+
+// Validate checks that the record is complete and its value matches the format of its type.
+func (r CreateRecord) Validate() error {
+	if r.Type == TypeSRV {
+		if r.SRV == nil {
+			return fmt.Errorf("SRV records require an srv block")
+		}
+		if r.Value != "" {
+			return fmt.Errorf("SRV records are described by the srv block, value must not be set")
+		}
+		if !hostname.MatchString(r.SRV.Target) {
+			return fmt.Errorf("the srv target %q is not a valid hostname", r.SRV.Target)
+		}
+	} else if r.SRV != nil {
+		return fmt.Errorf("an srv block is only allowed for SRV records, not %s", r.Type)
+	}
+
+	if r.Type == TypeMX && r.MXPriority == nil {
+		return fmt.Errorf("MX records require mx_priority")
+	}
+	if r.Type != TypeMX && r.MXPriority != nil {
+		return fmt.Errorf("mx_priority is only allowed for MX records, not %s", r.Type)
+	}
+
+	if len(r.Comment) > MaxCommentLength {
+		return fmt.Errorf("the comment must be at most %d characters long", MaxCommentLength)
+	}
+
+	return validateValue(r.Type, r.Value)
+}
+
+func validateValue(recordType, value string) error {
+	invalid := func(format string) error {
+		return fmt.Errorf("%q is not a valid %s record value, expected %s", value, recordType, format)
+	}
+
+	switch recordType {
+	case TypeA:
+		if ip := net.ParseIP(value); ip == nil || ip.To4() == nil {
+			return invalid("an IPv4 address")
+		}
+	case TypeAAAA:
+		if ip := net.ParseIP(value); ip == nil || ip.To4() != nil {
+			return invalid("an IPv6 address")
+		}
+	case TypeALIAS, TypeCNAME, TypeMX, TypeNS:
+		if !hostname.MatchString(value) {
+			return invalid("a hostname")
+		}
+	case TypeCAA:
+		if !caa.MatchString(value) {
+			return invalid(`<flags> <issue|issuewild|iodef> "<value>", e.g. 0 issue "letsencrypt.org"`)
+		}
+	case TypeHTTPS:
+		if !https.MatchString(value) {
+			return invalid(`<priority> <target> [params], e.g. 1 . alpn="h3,h2"`)
+		}
+	case TypeTXT:
+		if value == "" {
+			return invalid("a non empty string")
+		}
+		for _, c := range value {
+			if c < ' ' || c > '~' {
+				return invalid("printable ASCII characters")
+			}
+		}
+	case TypeSRV:
+		return nil
+	default:
+		return fmt.Errorf("unsupported record type %q, must be one of %s", recordType, strings.Join(Types, ", "))
+	}
+	return nil
+}
+
+// SRV returns the target of an SRV record. Vercel returns the priority on its own and
+// the rest as value in the form `<weight> <port> <target>`.
+func (r Record) SRV() (SRV, error) {
+	fields := strings.Fields(r.Value)
+	if len(fields) != 3 {
+		return SRV{}, fmt.Errorf("unable to parse SRV record value %q", r.Value)
+	}
+
+	weight, err := strconv.Atoi(fields[0])
+	if err != nil {
+		return SRV{}, fmt.Errorf("unable to parse weight of SRV record value %q: %w", r.Value, err)
+	}
+	port, err := strconv.Atoi(fields[1])
+	if err != nil {
+		return SRV{}, fmt.Errorf("unable to parse port of SRV record value %q: %w", r.Value, err)
+	}
+
+	return SRV{Priority: r.Priority, Weight: weight, Port: port, Target: fields[2]}, nil
+}
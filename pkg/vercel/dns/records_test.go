@@ -0,0 +1,62 @@
+package dns
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidate(t *testing.T) {
+	priority := 10
+
+	valid := []CreateRecord{
+		{Type: TypeA, Value: "76.76.21.21"},
+		{Type: TypeAAAA, Value: "2606:4700::6810:85e5"},
+		{Type: TypeCNAME, Value: "cname.vercel-dns.com"},
+		{Type: TypeCNAME, Value: "cname.vercel-dns.com."},
+		{Type: TypeALIAS, Value: "alias.vercel-dns.com"},
+		{Type: TypeNS, Value: "ns1.vercel-dns.com"},
+		{Type: TypeMX, Value: "mx1.improvmx.com", MXPriority: &priority},
+		{Type: TypeCAA, Value: `0 issue "letsencrypt.org"`},
+		{Type: TypeHTTPS, Value: `1 . alpn="h3,h2"`},
+		{Type: TypeTXT, Value: "v=spf1 include:_spf.google.com ~all"},
+		{Type: TypeSRV, SRV: &SRV{Priority: 10, Weight: 5, Port: 5060, Target: "sip.chronark.com"}},
+		{Type: TypeTXT, Value: "hello", Comment: strings.Repeat("a", MaxCommentLength)},
+	}
+	for _, record := range valid {
+		require.NoError(t, record.Validate(), "%+v", record)
+	}
+
+	invalid := []CreateRecord{
+		{Type: TypeA, Value: "2606:4700::6810:85e5"},
+		{Type: TypeA, Value: "chronark.com"},
+		{Type: TypeAAAA, Value: "76.76.21.21"},
+		{Type: TypeCNAME, Value: "not a hostname"},
+		{Type: TypeMX, Value: "mx1.improvmx.com"},
+		{Type: TypeA, Value: "76.76.21.21", MXPriority: &priority},
+		{Type: TypeCAA, Value: "0 issue letsencrypt.org"},
+		{Type: TypeCAA, Value: `0 unknown "letsencrypt.org"`},
+		{Type: TypeHTTPS, Value: "alpn=h3"},
+		{Type: TypeTXT, Value: ""},
+		{Type: TypeTXT, Value: "line\nbreak"},
+		{Type: TypeSRV},
+		{Type: TypeSRV, Value: "5 5060 sip.chronark.com", SRV: &SRV{Target: "sip.chronark.com"}},
+		{Type: TypeSRV, SRV: &SRV{Target: "not a hostname"}},
+		{Type: TypeA, Value: "76.76.21.21", SRV: &SRV{Target: "sip.chronark.com"}},
+		{Type: TypeTXT, Value: "hello", Comment: strings.Repeat("a", MaxCommentLength+1)},
+		{Type: "PTR", Value: "chronark.com"},
+	}
+	for _, record := range invalid {
+		require.Error(t, record.Validate(), "%+v", record)
+	}
+}
+
+func TestRecordSRV(t *testing.T) {
+	srv, err := Record{Type: TypeSRV, Priority: 10, Value: "5 5060 sip.chronark.com"}.SRV()
+	require.NoError(t, err)
+	require.Equal(t, SRV{Priority: 10, Weight: 5, Port: 5060, Target: "sip.chronark.com"}, srv)
+
+	_, err = Record{Type: TypeSRV, Value: "sip.chronark.com"}.SRV()
+	require.Error(t, err)
+}
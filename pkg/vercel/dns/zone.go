@@ -0,0 +1,405 @@
+package dns
+
+import (
+	"bufio"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// DefaultTTL is used for records of a zone file that has no $TTL directive and no TTL on the record itself.
+const DefaultTTL = 60
+
+// ParseZone reads the records of domain from a BIND zone file.
+// SOA records and the NS records of the apex are skipped, vercel manages those itself.
+// Names are returned relative to domain, records outside of the domain are an error.
+func ParseZone(zone string, domain string) ([]CreateRecord, error) {
+	domain = strings.ToLower(strings.TrimSuffix(domain, "."))
+	p := zoneParser{origin: domain, domain: domain, ttl: DefaultTTL}
+
+	records := []CreateRecord{}
+	lines, err := zoneLines(zone)
+	if err != nil {
+		return nil, err
+	}
+	for _, line := range lines {
+		record, ok, err := p.parse(line)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line.number, err)
+		}
+		if ok {
+			records = append(records, record)
+		}
+	}
+	return records, nil
+}
+
+type zoneLine struct {
+	number int
+
+	// The line started with whitespace, so the record belongs to the previous owner.
+	continued bool
+
+	tokens []string
+
+	// The last comment of the entry, it becomes the comment of the record.
+	comment string
+}
+
+// zoneLines splits a zone file into tokens per entry. Comments are kept apart from the tokens and entries spanning
+// multiple lines with parentheses are joined. Quoted strings are kept as a single token including their quotes.
+func zoneLines(zone string) ([]zoneLine, error) {
+	lines := []zoneLine{}
+	var current *zoneLine
+	depth := 0
+
+	scanner := bufio.NewScanner(strings.NewReader(zone))
+	number := 0
+	for scanner.Scan() {
+		number++
+		text := scanner.Text()
+		if current == nil {
+			current = &zoneLine{number: number, continued: text != "" && (text[0] == ' ' || text[0] == '\t')}
+		}
+
+		token := strings.Builder{}
+		quoted := false
+		flush := func() {
+			if token.Len() > 0 {
+				current.tokens = append(current.tokens, token.String())
+				token.Reset()
+			}
+		}
+	scan:
+		for i := 0; i < len(text); i++ {
+			c := text[i]
+			switch {
+			case quoted:
+				token.WriteByte(c)
+				if c == '\\' && i+1 < len(text) {
+					i++
+					token.WriteByte(text[i])
+				} else if c == '"' {
+					quoted = false
+				}
+			case c == '"':
+				quoted = true
+				token.WriteByte(c)
+			case c == ';':
+				current.comment = strings.TrimSpace(text[i+1:])
+				break scan
+			case c == '(':
+				flush()
+				depth++
+			case c == ')':
+				flush()
+				if depth == 0 {
+					return nil, fmt.Errorf("line %d: unexpected )", number)
+				}
+				depth--
+			case c == ' ' || c == '\t':
+				flush()
+			default:
+				token.WriteByte(c)
+			}
+		}
+		if quoted {
+			return nil, fmt.Errorf("line %d: unterminated quoted string", number)
+		}
+		flush()
+
+		if depth == 0 {
+			if len(current.tokens) > 0 {
+				lines = append(lines, *current)
+			}
+			current = nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("Unable to read zone file: %w", err)
+	}
+	if depth > 0 {
+		return nil, fmt.Errorf("line %d: missing )", current.number)
+	}
+	return lines, nil
+}
+
+type zoneParser struct {
+	// The domain the records belong to.
+	domain string
+
+	// The current $ORIGIN, relative names are appended to it.
+	origin string
+
+	// The current $TTL.
+	ttl int
+
+	// The owner of the previous record, used by records without an owner.
+	owner string
+}
+
+// parse turns a single entry into a record. ok is false for directives and skipped records.
+func (p *zoneParser) parse(line zoneLine) (record CreateRecord, ok bool, err error) {
+	tokens := line.tokens
+
+	switch strings.ToUpper(tokens[0]) {
+	case "$ORIGIN":
+		if len(tokens) != 2 {
+			return CreateRecord{}, false, fmt.Errorf("$ORIGIN expects a single domain")
+		}
+		p.origin = p.absolute(tokens[1])
+		return CreateRecord{}, false, nil
+	case "$TTL":
+		if len(tokens) != 2 {
+			return CreateRecord{}, false, fmt.Errorf("$TTL expects a single value")
+		}
+		p.ttl, err = parseTTL(tokens[1])
+		return CreateRecord{}, false, err
+	case "$INCLUDE":
+		return CreateRecord{}, false, fmt.Errorf("$INCLUDE is not supported")
+	}
+
+	if !line.continued {
+		p.owner = p.absolute(tokens[0])
+		tokens = tokens[1:]
+	} else if p.owner == "" {
+		return CreateRecord{}, false, fmt.Errorf("the first record must have an owner")
+	}
+
+	// TTL and class are both optional and may appear in any order
+	ttl := p.ttl
+	for len(tokens) > 0 {
+		if strings.EqualFold(tokens[0], "IN") {
+			tokens = tokens[1:]
+		} else if recordTTL, err := parseTTL(tokens[0]); err == nil {
+			ttl = recordTTL
+			tokens = tokens[1:]
+		} else {
+			break
+		}
+	}
+	if len(tokens) == 0 {
+		return CreateRecord{}, false, fmt.Errorf("missing record type")
+	}
+
+	recordType := strings.ToUpper(tokens[0])
+	data := tokens[1:]
+
+	name, err := p.relative(p.owner)
+	if err != nil {
+		return CreateRecord{}, false, err
+	}
+
+	if recordType == "SOA" || (recordType == TypeNS && name == "") {
+		return CreateRecord{}, false, nil
+	}
+
+	record = CreateRecord{Type: recordType, Name: name, TTL: ttl, Comment: line.comment}
+	switch recordType {
+	case TypeA, TypeAAAA:
+		if len(data) != 1 {
+			return CreateRecord{}, false, fmt.Errorf("%s records expect a single address", recordType)
+		}
+		record.Value = data[0]
+	case TypeALIAS, TypeCNAME, TypeNS:
+		if len(data) != 1 {
+			return CreateRecord{}, false, fmt.Errorf("%s records expect a single hostname", recordType)
+		}
+		record.Value = p.absolute(data[0])
+	case TypeMX:
+		if len(data) != 2 {
+			return CreateRecord{}, false, fmt.Errorf("MX records expect a priority and a hostname")
+		}
+		priority, err := strconv.Atoi(data[0])
+		if err != nil {
+			return CreateRecord{}, false, fmt.Errorf("invalid MX priority %q", data[0])
+		}
+		record.MXPriority = &priority
+		record.Value = p.absolute(data[1])
+	case TypeSRV:
+		if len(data) != 4 {
+			return CreateRecord{}, false, fmt.Errorf("SRV records expect a priority, weight, port and target")
+		}
+		numbers := make([]int, 3)
+		for i := range numbers {
+			numbers[i], err = strconv.Atoi(data[i])
+			if err != nil {
+				return CreateRecord{}, false, fmt.Errorf("invalid SRV value %q", data[i])
+			}
+		}
+		record.SRV = &SRV{Priority: numbers[0], Weight: numbers[1], Port: numbers[2], Target: p.absolute(data[3])}
+	case TypeTXT:
+		value := strings.Builder{}
+		for _, s := range data {
+			value.WriteString(unquote(s))
+		}
+		record.Value = value.String()
+	case TypeCAA, TypeHTTPS:
+		record.Value = strings.Join(data, " ")
+	default:
+		return CreateRecord{}, false, fmt.Errorf("unsupported record type %q, must be one of %s", recordType, strings.Join(Types, ", "))
+	}
+
+	err = record.Validate()
+	if err != nil {
+		return CreateRecord{}, false, err
+	}
+	return record, true, nil
+}
+
+// absolute resolves a name against the current origin and returns it without the trailing dot.
+func (p *zoneParser) absolute(name string) string {
+	switch {
+	case name == "@":
+		return p.origin
+	case strings.HasSuffix(name, "."):
+		return strings.ToLower(strings.TrimSuffix(name, "."))
+	default:
+		return strings.ToLower(name) + "." + p.origin
+	}
+}
+
+// relative returns name relative to the domain, the apex is an empty string.
+func (p *zoneParser) relative(name string) (string, error) {
+	if name == p.domain {
+		return "", nil
+	}
+	if !strings.HasSuffix(name, "."+p.domain) {
+		return "", fmt.Errorf("%s is not part of %s", name, p.domain)
+	}
+	return strings.TrimSuffix(name, "."+p.domain), nil
+}
+
+// ttlUnits are the BIND units of a TTL in seconds.
+var ttlUnits = map[rune]int{'s': 1, 'm': 60, 'h': 60 * 60, 'd': 24 * 60 * 60, 'w': 7 * 24 * 60 * 60}
+
+// parseTTL reads a TTL in seconds or with BIND units, e.g. `3600`, `1h` or `1h30m`.
+func parseTTL(value string) (int, error) {
+	invalid := fmt.Errorf("invalid TTL %q, expected a number of seconds or a duration like 1h30m", value)
+	if ttl, err := strconv.Atoi(value); err == nil {
+		if ttl < 0 {
+			return 0, invalid
+		}
+		return ttl, nil
+	}
+
+	ttl, number := 0, ""
+	for _, c := range strings.ToLower(value) {
+		if c >= '0' && c <= '9' {
+			number += string(c)
+			continue
+		}
+		unit, ok := ttlUnits[c]
+		if !ok || number == "" {
+			return 0, invalid
+		}
+		n, err := strconv.Atoi(number)
+		if err != nil {
+			return 0, invalid
+		}
+		ttl += n * unit
+		number = ""
+	}
+	if number != "" || value == "" {
+		return 0, invalid
+	}
+	return ttl, nil
+}
+
+func unquote(s string) string {
+	if len(s) < 2 || s[0] != '"' || s[len(s)-1] != '"' {
+		return s
+	}
+	s = s[1 : len(s)-1]
+	out := strings.Builder{}
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+1 < len(s) {
+			i++
+		}
+		out.WriteByte(s[i])
+	}
+	return out.String()
+}
+
+// RenderZone writes the records of domain as an RFC 1035 zone file. Records are sorted by name,
+// type and value, so the same records always render the same zone. Comments are kept as zone file comments.
+func RenderZone(domain string, records []Record) (string, error) {
+	domain = strings.TrimSuffix(domain, ".")
+	sorted := append([]Record{}, records...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Name != sorted[j].Name {
+			return sorted[i].Name < sorted[j].Name
+		}
+		if sorted[i].Type != sorted[j].Type {
+			return sorted[i].Type < sorted[j].Type
+		}
+		return sorted[i].content() < sorted[j].content()
+	})
+
+	zone := strings.Builder{}
+	fmt.Fprintf(&zone, "$ORIGIN %s.\n", domain)
+	for _, record := range sorted {
+		data, err := renderData(record)
+		if err != nil {
+			return "", err
+		}
+
+		owner := record.Name
+		if owner == "" {
+			owner = "@"
+		}
+		fmt.Fprintf(&zone, "%s\t%d\tIN\t%s\t%s", owner, record.TTL, record.Type, data)
+		if record.Comment != "" {
+			fmt.Fprintf(&zone, " ; %s", strings.ReplaceAll(record.Comment, "\n", " "))
+		}
+		zone.WriteString("\n")
+	}
+	return zone.String(), nil
+}
+
+// renderData returns the data of a record in zone file notation.
+func renderData(record Record) (string, error) {
+	switch record.Type {
+	case TypeALIAS, TypeCNAME, TypeNS:
+		return fqdn(record.Value), nil
+	case TypeMX:
+		return fmt.Sprintf("%d %s", record.MXPriority, fqdn(record.Value)), nil
+	case TypeSRV:
+		srv, err := record.SRV()
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("%d %d %d %s", srv.Priority, srv.Weight, srv.Port, fqdn(srv.Target)), nil
+	case TypeTXT:
+		return quote(record.Value), nil
+	default:
+		return record.Value, nil
+	}
+}
+
+func fqdn(name string) string {
+	if strings.HasSuffix(name, ".") {
+		return name
+	}
+	return name + "."
+}
+
+// quote splits a TXT value into quoted strings of at most 255 characters each.
+func quote(value string) string {
+	parts := []string{}
+	for {
+		chunk := value
+		if len(chunk) > 255 {
+			chunk = chunk[:255]
+		}
+		value = value[len(chunk):]
+
+		chunk = strings.ReplaceAll(chunk, `\`, `\\`)
+		chunk = strings.ReplaceAll(chunk, `"`, `\"`)
+		parts = append(parts, `"`+chunk+`"`)
+		if value == "" {
+			return strings.Join(parts, " ")
+		}
+	}
+}
@@ -0,0 +1,137 @@
+package dns
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const testZone = `
+$ORIGIN chronark.com.
+$TTL 3600
+@       IN SOA ns1.vercel-dns.com. hostmaster.chronark.com. (
+            2021010101 ; serial
+            7200       ; refresh
+            3600       ; retry
+            1209600    ; expire
+            3600 )     ; minimum
+@       IN NS    ns1.vercel-dns.com.
+@          A     76.76.21.21
+www     60 CNAME cname.vercel-dns.com.
+        IN TXT   "v=spf1 " "include:_spf.google.com ~all" ; joined
+@       MX 10    mx1.improvmx.com.
+mail    IN 300 MX 0 @
+_sip._tcp SRV 10 5 5060 sip
+@       CAA 0 issue "letsencrypt.org"
+sub     NS ns1.example.com.
+`
+
+func TestParseZone(t *testing.T) {
+	records, err := ParseZone(testZone, "chronark.com")
+	require.NoError(t, err)
+
+	ten, zero := 10, 0
+	require.Equal(t, []CreateRecord{
+		{Type: TypeA, Name: "", Value: "76.76.21.21", TTL: 3600},
+		{Type: TypeCNAME, Name: "www", Value: "cname.vercel-dns.com", TTL: 60},
+		{Type: TypeTXT, Name: "www", Value: "v=spf1 include:_spf.google.com ~all", TTL: 3600, Comment: "joined"},
+		{Type: TypeMX, Name: "", Value: "mx1.improvmx.com", TTL: 3600, MXPriority: &ten},
+		{Type: TypeMX, Name: "mail", Value: "chronark.com", TTL: 300, MXPriority: &zero},
+		{Type: TypeSRV, Name: "_sip._tcp", TTL: 3600, SRV: &SRV{Priority: 10, Weight: 5, Port: 5060, Target: "sip.chronark.com"}},
+		{Type: TypeCAA, Name: "", Value: `0 issue "letsencrypt.org"`, TTL: 3600},
+		{Type: TypeNS, Name: "sub", Value: "ns1.example.com", TTL: 3600},
+	}, records)
+}
+
+func TestParseZoneTTLUnits(t *testing.T) {
+	records, err := ParseZone("$TTL 1d\n@ A 76.76.21.21\nwww 1h30M CNAME cname.vercel-dns.com.\n", "chronark.com")
+	require.NoError(t, err)
+	require.Equal(t, []CreateRecord{
+		{Type: TypeA, Name: "", Value: "76.76.21.21", TTL: 86400},
+		{Type: TypeCNAME, Name: "www", Value: "cname.vercel-dns.com", TTL: 5400},
+	}, records)
+}
+
+func TestParseZoneErrors(t *testing.T) {
+	invalid := map[string]string{
+		"outside of the domain": "www.example.com. A 76.76.21.21",
+		"unsupported type":      "@ PTR chronark.com.",
+		"invalid value":         "@ A chronark.com.",
+		"missing owner":         "  A 76.76.21.21",
+		"include":               "$INCLUDE other.zone",
+		"unbalanced":            "@ SOA ns1.vercel-dns.com. hostmaster.chronark.com. ( 1 2 3 4",
+		"unterminated quote":    `@ TXT "hello`,
+		"unknown ttl unit":      "$TTL 1x",
+		"ttl without number":    "$TTL h",
+	}
+	for name, zone := range invalid {
+		_, err := ParseZone(zone, "chronark.com")
+		require.Error(t, err, name)
+	}
+}
+
+func TestDiff(t *testing.T) {
+	ten := 10
+	existing := []Record{
+		{Id: "rec_keep", Type: TypeA, Name: "", Value: "76.76.21.21", TTL: 60},
+		{Id: "rec_ttl", Type: TypeCNAME, Name: "www", Value: "cname.vercel-dns.com", TTL: 60},
+		{Id: "rec_value", Type: TypeTXT, Name: "", Value: "old", TTL: 60},
+		{Id: "rec_manual", Type: TypeA, Name: "manual", Value: "1.1.1.1", TTL: 60},
+		{Id: "rec_system", Type: TypeALIAS, Name: "*", Value: "cname.vercel-dns.com", TTL: 60, Creator: CreatorSystem},
+		{Id: "rec_srv", Type: TypeSRV, Name: "_sip._tcp", Value: "5 5060 sip.chronark.com", Priority: 10, TTL: 60},
+	}
+	desired := []CreateRecord{
+		{Type: TypeA, Name: "", Value: "76.76.21.21", TTL: 60},
+		{Type: TypeCNAME, Name: "www", Value: "cname.vercel-dns.com.", TTL: 300},
+		{Type: TypeTXT, Name: "", Value: "new", TTL: 60},
+		{Type: TypeMX, Name: "", Value: "mx1.improvmx.com", TTL: 60, MXPriority: &ten},
+		{Type: TypeSRV, Name: "_sip._tcp", SRV: &SRV{Priority: 10, Weight: 5, Port: 5060, Target: "sip.chronark.com"}, TTL: 60},
+	}
+
+	changes := Diff(desired, existing)
+	require.Equal(t, []CreateRecord{desired[3]}, changes.Create)
+	require.Equal(t, []RecordUpdate{
+		{ID: "rec_ttl", Record: UpdateRecord{Name: "www", Value: "cname.vercel-dns.com.", TTL: 300}},
+		{ID: "rec_value", Record: UpdateRecord{Name: "", Value: "new", TTL: 60}},
+	}, changes.Update)
+	require.Equal(t, []Record{existing[3]}, changes.Delete)
+	require.Equal(t, []Record{
+		existing[0],
+		{Id: "rec_ttl", Type: TypeCNAME, Name: "www", Value: "cname.vercel-dns.com.", TTL: 300},
+		{Id: "rec_value", Type: TypeTXT, Name: "", Value: "new", TTL: 60},
+		existing[4],
+		existing[5],
+		{Type: TypeMX, Name: "", Value: "mx1.improvmx.com", TTL: 60, MXPriority: 10},
+	}, changes.Remaining(existing), "the manual record is gone after the changes")
+
+	require.True(t, Diff(desired[:1], existing[:1]).Empty())
+	require.True(t, Diff(nil, existing[4:5]).Empty(), "system records are never deleted")
+}
+
+func TestRenderZone(t *testing.T) {
+	records := []Record{
+		{Type: TypeTXT, Name: "", Value: `v=spf1 "quoted" ~all`, TTL: 60, Comment: "spf"},
+		{Type: TypeA, Name: "", Value: "76.76.21.21", TTL: 60},
+		{Type: TypeCNAME, Name: "www", Value: "cname.vercel-dns.com", TTL: 300},
+		{Type: TypeMX, Name: "", Value: "mx1.improvmx.com", MXPriority: 10, TTL: 60},
+		{Type: TypeSRV, Name: "_sip._tcp", Value: "5 5060 sip.chronark.com", Priority: 10, TTL: 60},
+		{Type: TypeHTTPS, Name: "", Value: `1 . alpn="h3,h2"`, TTL: 60},
+		{Type: TypeCAA, Name: "", Value: `0 issue "letsencrypt.org"`, TTL: 60},
+	}
+
+	zone, err := RenderZone("chronark.com", records)
+	require.NoError(t, err)
+	require.Equal(t, `$ORIGIN chronark.com.
+@	60	IN	A	76.76.21.21
+@	60	IN	CAA	0 issue "letsencrypt.org"
+@	60	IN	HTTPS	1 . alpn="h3,h2"
+@	60	IN	MX	10 mx1.improvmx.com.
+@	60	IN	TXT	"v=spf1 \"quoted\" ~all" ; spf
+_sip._tcp	60	IN	SRV	10 5 5060 sip.chronark.com.
+www	300	IN	CNAME	cname.vercel-dns.com.
+`, zone)
+
+	parsed, err := ParseZone(zone, "chronark.com")
+	require.NoError(t, err)
+	require.True(t, Diff(parsed, records).Empty(), "rendering and parsing must not change the records")
+}
@@ -1,14 +1,69 @@
 package domain
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"github.com/chronark/terraform-provider-vercel/pkg/vercel/httpApi"
 	"net/http"
+	"net/url"
+)
+
+// Methods of adding a domain.
+const (
+	// MethodAdd adds a domain that is registered elsewhere.
+	MethodAdd = "add"
+
+	// MethodBuy registers a new domain through vercel.
+	MethodBuy = "buy"
+
+	// MethodTransferIn moves a domain from another registrar to vercel.
+	MethodTransferIn = "transfer-in"
+)
+
+// Methods are all ways of adding a domain.
+var Methods = []string{MethodAdd, MethodBuy, MethodTransferIn}
+
+// ServiceTypeRegistered is the service type of domains that are registered through vercel.
+const ServiceTypeRegistered = "zeit.world"
+
+// Price types for the price check.
+const (
+	PriceTypeNew      = "new"
+	PriceTypeTransfer = "transfer"
 )
 
 type CreateDomain struct {
 	Name string `json:"name"`
+
+	// One of Methods, defaults to MethodAdd. Domains are bought with Buy.
+	Method string `json:"method,omitempty"`
+
+	// The authorization code of the current registrar, required for MethodTransferIn.
+	AuthCode string `json:"authCode,omitempty"`
+
+	// The price the transfer is expected to cost, the transfer fails if the price differs.
+	ExpectedPrice int `json:"expectedPrice,omitempty"`
+}
+
+// BuyDomain registers a new domain.
+type BuyDomain struct {
+	Name string `json:"name"`
+
+	// The price the purchase is expected to cost, the purchase fails if the price differs.
+	ExpectedPrice int `json:"expectedPrice"`
+
+	// Whether the domain is renewed automatically before it expires.
+	Renew bool `json:"renew"`
+}
+
+// Price is the cost of registering, renewing or transferring a domain for a period.
+type Price struct {
+	// The price in US dollars.
+	Price int `json:"price"`
+
+	// The number of years the price covers.
+	Period int `json:"period"`
 }
 type Domain struct {
 	ID                  string   `json:"id"`
@@ -20,7 +75,10 @@ type Domain struct {
 	CreatedAt           int64    `json:"createdAt"`
 	ExpiresAt           int64    `json:"expiresAt"`
 	BoughtAt            int64    `json:"boughtAt"`
+	TransferStartedAt   int64    `json:"transferStartedAt"`
 	TransferredAt       int64    `json:"transferredAt"`
+	Renew               bool     `json:"renew"`
+	Registrar           string   `json:"registrar"`
 	VerificationRecord  string   `json:"verificationRecord"`
 	Verified            bool     `json:"verified"`
 	Nameservers         []string `json:"nameservers"`
@@ -36,34 +94,127 @@ type Handler struct {
 	Api httpApi.API
 }
 
-func (h *Handler) Create(name string, teamId string) (string, error) {
+func (h *Handler) Create(ctx context.Context, name string, teamId string) (string, error) {
 	url := "/v4/domains"
 	if teamId != "" {
 		url = fmt.Sprintf("%s?teamId=%s", url, teamId)
 	}
-	res, err := h.Api.Request(http.MethodPost, url, CreateDomain{Name: name})
+	res, err := h.Api.RequestWithContext(ctx, http.MethodPost, url, CreateDomain{Name: name})
 	if err != nil {
 		return "", err
 	}
 	defer res.Body.Close()
 
-	var createdDomain Domain
-	err = json.NewDecoder(res.Body).Decode(&createdDomain)
+	// POST /v4/domains wraps the new domain in a `domain` object
+	var createDomainResponse struct {
+		Domain Domain `json:"domain"`
+	}
+	err = json.NewDecoder(res.Body).Decode(&createDomainResponse)
+	if err != nil {
+		return "", fmt.Errorf("Unable to unmarshal create domain response: %w", err)
+	}
+
+	return createDomainResponse.Domain.ID, nil
+}
+
+// Price returns what registering or transferring the domain costs, priceType is one of the PriceType constants.
+func (h *Handler) Price(ctx context.Context, name string, priceType string, teamId string) (Price, error) {
+	query := url.Values{}
+	query.Set("name", name)
+	query.Set("type", priceType)
+	if teamId != "" {
+		query.Set("teamId", teamId)
+	}
+
+	res, err := h.Api.RequestWithContext(ctx, http.MethodGet, fmt.Sprintf("/v4/domains/price?%s", query.Encode()), nil)
+	if err != nil {
+		return Price{}, fmt.Errorf("Unable to fetch the price of %s: %w", name, err)
+	}
+	defer res.Body.Close()
+
+	var price Price
+	err = json.NewDecoder(res.Body).Decode(&price)
+	if err != nil {
+		return Price{}, fmt.Errorf("Unable to unmarshal price response: %w", err)
+	}
+	return price, nil
+}
+
+// Buy registers a new domain and returns its id. Vercel charges the price of the domain right away.
+func (h *Handler) Buy(ctx context.Context, buy BuyDomain, teamId string) (string, error) {
+	url := "/v5/domains/buy"
+	if teamId != "" {
+		url = fmt.Sprintf("%s?teamId=%s", url, teamId)
+	}
+	res, err := h.Api.RequestWithContext(ctx, http.MethodPost, url, buy)
+	if err != nil {
+		return "", fmt.Errorf("Unable to buy domain %s: %w", buy.Name, err)
+	}
+	defer res.Body.Close()
+
+	var buyDomainResponse struct {
+		Domain struct {
+			UID string `json:"uid"`
+		} `json:"domain"`
+	}
+	err = json.NewDecoder(res.Body).Decode(&buyDomainResponse)
+	if err != nil {
+		return "", fmt.Errorf("Unable to unmarshal buy response: %w", err)
+	}
+	return buyDomainResponse.Domain.UID, nil
+}
+
+// TransferIn starts the transfer of a domain from another registrar and returns its id.
+// The transfer finishes asynchronously, see `TransferredAt`.
+func (h *Handler) TransferIn(ctx context.Context, name string, authCode string, expectedPrice int, teamId string) (string, error) {
+	url := "/v4/domains"
+	if teamId != "" {
+		url = fmt.Sprintf("%s?teamId=%s", url, teamId)
+	}
+	transfer := CreateDomain{Name: name, Method: MethodTransferIn, AuthCode: authCode, ExpectedPrice: expectedPrice}
+	res, err := h.Api.RequestWithContext(ctx, http.MethodPost, url, transfer)
 	if err != nil {
-		return "", nil
+		return "", fmt.Errorf("Unable to transfer domain %s: %w", name, err)
+	}
+	defer res.Body.Close()
+
+	var transferDomainResponse struct {
+		Domain Domain `json:"domain"`
+	}
+	err = json.NewDecoder(res.Body).Decode(&transferDomainResponse)
+	if err != nil {
+		return "", fmt.Errorf("Unable to unmarshal transfer response: %w", err)
+	}
+	return transferDomainResponse.Domain.ID, nil
+}
+
+// SetRenew turns the automatic renewal of a domain registered through vercel on or off.
+func (h *Handler) SetRenew(ctx context.Context, name string, renew bool, teamId string) error {
+	url := fmt.Sprintf("/v3/domains/%s", name)
+	if teamId != "" {
+		url = fmt.Sprintf("%s?teamId=%s", url, teamId)
 	}
+	payload := struct {
+		Op    string `json:"op"`
+		Renew bool   `json:"renew"`
+	}{Op: "update", Renew: renew}
 
-	return createdDomain.ID, nil
+	res, err := h.Api.RequestWithContext(ctx, http.MethodPatch, url, payload)
+	if err != nil {
+		return fmt.Errorf("Unable to update the renewal of %s: %w", name, err)
+	}
+	defer res.Body.Close()
+	return nil
 }
 
 // Read returns metadata about a domain
-func (h *Handler) Read(domainName string, teamId string) (domain Domain, err error) {
+func (h *Handler) Read(ctx context.Context, domainName string, teamId string) (domain Domain, err error) {
 	url := fmt.Sprintf("/v4/domains/%s", domainName)
 	if teamId != "" {
 		url = fmt.Sprintf("%s?teamId=%s", url, teamId)
 	}
 
-	res, err := h.Api.Request("GET", url, nil)
+	res, err := h.Api.RequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
 		return Domain{}, fmt.Errorf("Unable to fetch domain from vercel: %w", err)
 	}
@@ -81,12 +232,37 @@ func (h *Handler) Read(domainName string, teamId string) (domain Domain, err err
 	return getDomainResponse.Domain, nil
 }
 
-func (h *Handler) Delete(domainName string, teamId string) error {
+// Verify asks vercel to check the nameservers or the TXT record of the domain again.
+// It fails as long as the domain can not be verified.
+func (h *Handler) Verify(ctx context.Context, domainName string, teamId string) (Domain, error) {
+	url := fmt.Sprintf("/v4/domains/%s/verify", domainName)
+	if teamId != "" {
+		url = fmt.Sprintf("%s?teamId=%s", url, teamId)
+	}
+
+	res, err := h.Api.RequestWithContext(ctx, http.MethodPost, url, nil)
+	if err != nil {
+		return Domain{}, fmt.Errorf("Unable to verify domain: %w", err)
+	}
+	defer res.Body.Close()
+
+	var verifyDomainResponse struct {
+		Domain Domain `json:"domain"`
+	}
+	err = json.NewDecoder(res.Body).Decode(&verifyDomainResponse)
+	if err != nil {
+		return Domain{}, fmt.Errorf("Unable to unmarshal domain response: %w", err)
+	}
+
+	return verifyDomainResponse.Domain, nil
+}
+
+func (h *Handler) Delete(ctx context.Context, domainName string, teamId string) error {
 	url := fmt.Sprintf("/v4/domains/%s", domainName)
 	if teamId != "" {
 		url = fmt.Sprintf("%s?teamId=%s", url, teamId)
 	}
-	res, err := h.Api.Request("DELETE", url, nil)
+	res, err := h.Api.RequestWithContext(ctx, "DELETE", url, nil)
 	if err != nil {
 		return fmt.Errorf("Unable to delete domain: %w", err)
 	}
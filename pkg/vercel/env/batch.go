@@ -0,0 +1,176 @@
+package env
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// BatchSize is the number of variables created with a single request.
+const BatchSize = 50
+
+// EnvUpdate is an existing variable that has to be changed.
+type EnvUpdate struct {
+	ID  string
+	Env CreateOrUpdateEnv
+}
+
+// Changes turn the existing variables of a project into the desired ones.
+type Changes struct {
+	Create []CreateOrUpdateEnv
+	Update []EnvUpdate
+	Delete []Env
+}
+
+// Empty reports whether the variables are already in the desired state.
+func (c Changes) Empty() bool {
+	return len(c.Create) == 0 && len(c.Update) == 0 && len(c.Delete) == 0
+}
+
+// Diff compares the desired variables of a project with the existing ones.
+// Variables are matched by key, target and git branch first, the remaining variables with the same
+// key are updated in place. Every other existing variable is deleted.
+// Existing variables with a hidden value must carry the last known value, an empty value is always updated.
+func Diff(desired []CreateOrUpdateEnv, existing []Env) Changes {
+	return DiffManaged(desired, existing, func(Env) bool { return true })
+}
+
+// DiffManaged is Diff for projects with variables managed elsewhere. An unmanaged variable is only changed
+// when a desired variable has the same key, target and git branch, it is never moved to another target or deleted.
+func DiffManaged(desired []CreateOrUpdateEnv, existing []Env, managed func(Env) bool) Changes {
+	changes := Changes{}
+	matched := make([]bool, len(existing))
+
+	unmatched := []CreateOrUpdateEnv{}
+	for _, want := range desired {
+		found := false
+		for i, have := range existing {
+			if matched[i] || have.Scope() != want.Scope() {
+				continue
+			}
+			matched[i] = true
+			found = true
+			if have.Type != want.Type || have.Value != want.Value || (HidesValue(have.Type) && have.Value == "") {
+				changes.Update = append(changes.Update, EnvUpdate{ID: have.ID, Env: want})
+			}
+			break
+		}
+		if !found {
+			unmatched = append(unmatched, want)
+		}
+	}
+
+	for _, want := range unmatched {
+		found := false
+		for i, have := range existing {
+			if matched[i] || have.Key != want.Key || !managed(have) {
+				continue
+			}
+			matched[i] = true
+			found = true
+			changes.Update = append(changes.Update, EnvUpdate{ID: have.ID, Env: want})
+			break
+		}
+		if !found {
+			changes.Create = append(changes.Create, want)
+		}
+	}
+
+	for i, have := range existing {
+		if !matched[i] && managed(have) {
+			changes.Delete = append(changes.Delete, have)
+		}
+	}
+	return changes
+}
+
+// Scope identifies a variable among all variables of a project by its key, targets and git branch.
+func (e Env) Scope() string {
+	return scope(e.Key, e.Target, e.GitBranch)
+}
+
+// Scope identifies a variable among all variables of a project by its key, targets and git branch.
+func (e CreateOrUpdateEnv) Scope() string {
+	branch := ""
+	if e.GitBranch != nil {
+		branch = *e.GitBranch
+	}
+	return scope(e.Key, e.Target, branch)
+}
+
+func scope(key string, target []string, branch string) string {
+	sorted := append([]string{}, target...)
+	sort.Strings(sorted)
+	return fmt.Sprintf("%s/%s/%s", key, strings.Join(sorted, ","), branch)
+}
+
+// CreateMany creates multiple variables with a single request.
+func (h *Handler) CreateMany(ctx context.Context, projectID string, envs []CreateOrUpdateEnv, teamId string) ([]Env, error) {
+	url := fmt.Sprintf("/v10/projects/%s/env", projectID)
+	if teamId != "" {
+		url = fmt.Sprintf("%s?teamId=%s", url, teamId)
+	}
+	res, err := h.Api.RequestWithContext(ctx, http.MethodPost, url, envs)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to create environment variables: %w", err)
+	}
+	defer res.Body.Close()
+
+	var createResponse struct {
+		Created []Env `json:"created"`
+		Failed  []struct {
+			Error struct {
+				Code    string `json:"code"`
+				Message string `json:"message"`
+				Key     string `json:"key"`
+			} `json:"error"`
+		} `json:"failed"`
+	}
+	err = json.NewDecoder(res.Body).Decode(&createResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(createResponse.Failed) > 0 {
+		failures := []string{}
+		for _, failed := range createResponse.Failed {
+			failures = append(failures, fmt.Sprintf("%s: %s", failed.Error.Key, failed.Error.Message))
+		}
+		return createResponse.Created, fmt.Errorf("Unable to create environment variables: %s", strings.Join(failures, ", "))
+	}
+	return createResponse.Created, nil
+}
+
+// Apply makes the changes to the variables of a project. Variables are deleted first, so a variable
+// can be replaced by one with the same key. Vercel has no batch endpoint for updates and deletions,
+// they are sent one request at a time, only new variables are created in batches of BatchSize.
+func (h *Handler) Apply(ctx context.Context, projectID string, changes Changes, teamId string) error {
+	for _, e := range changes.Delete {
+		err := h.Delete(ctx, projectID, e.ID, teamId)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, update := range changes.Update {
+		err := h.Update(ctx, projectID, update.ID, update.Env, teamId)
+		if err != nil {
+			return err
+		}
+	}
+
+	for start := 0; start < len(changes.Create); start += BatchSize {
+		end := start + BatchSize
+		if end > len(changes.Create) {
+			end = len(changes.Create)
+		}
+		_, err := h.CreateMany(ctx, projectID, changes.Create[start:end], teamId)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
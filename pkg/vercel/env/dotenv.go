@@ -0,0 +1,139 @@
+package env
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// DotenvEntry is a single variable of a dotenv file.
+type DotenvEntry struct {
+	Key   string
+	Value string
+}
+
+var dotenvKey = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_.-]*$`)
+
+// ParseDotenv reads the variables of a dotenv file in the order they first appear, a repeated key overwrites
+// the earlier value. Lines may start with `export`, values may be quoted with double quotes, which
+// support escapes like `\n`, or with single quotes or backticks, which are taken literally.
+// Quoted values can span multiple lines. `#` starts a comment at the beginning of a line or after whitespace.
+func ParseDotenv(content string) ([]DotenvEntry, error) {
+	entries := []DotenvEntry{}
+	index := map[string]int{}
+
+	lines := strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n")
+	for i := 0; i < len(lines); i++ {
+		number := i + 1
+		line := strings.TrimSpace(lines[i])
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if strings.HasPrefix(line, "export ") || strings.HasPrefix(line, "export\t") {
+			line = strings.TrimSpace(line[len("export"):])
+		}
+
+		separator := strings.Index(line, "=")
+		if separator < 0 {
+			return nil, fmt.Errorf("line %d: expected KEY=value", number)
+		}
+		key := strings.TrimSpace(line[:separator])
+		if !dotenvKey.MatchString(key) {
+			return nil, fmt.Errorf("line %d: %q is not a valid variable name", number, key)
+		}
+
+		rest := strings.TrimLeft(line[separator+1:], " \t")
+		var value string
+		if rest != "" && strings.ContainsRune("\"'`", rune(rest[0])) {
+			// Quoted values continue on the following lines until the closing quote
+			quote := rest[0]
+			raw := rest[1:]
+			end := closingQuote(raw, quote)
+			for end < 0 && i+1 < len(lines) {
+				i++
+				raw += "\n" + lines[i]
+				end = closingQuote(raw, quote)
+			}
+			if end < 0 {
+				return nil, fmt.Errorf("line %d: missing closing %c", number, quote)
+			}
+
+			trailing := strings.TrimSpace(raw[end+1:])
+			if trailing != "" && !strings.HasPrefix(trailing, "#") {
+				return nil, fmt.Errorf("line %d: unexpected %q after the closing %c", number, trailing, quote)
+			}
+
+			value = raw[:end]
+			if quote == '"' {
+				value = unescape(value)
+			}
+		} else {
+			value = rest
+			if comment := strings.Index(value, " #"); comment >= 0 {
+				value = value[:comment]
+			}
+			if comment := strings.Index(value, "\t#"); comment >= 0 {
+				value = value[:comment]
+			}
+			value = strings.TrimSpace(value)
+		}
+
+		if j, ok := index[key]; ok {
+			entries[j].Value = value
+			continue
+		}
+		index[key] = len(entries)
+		entries = append(entries, DotenvEntry{Key: key, Value: value})
+	}
+	return entries, nil
+}
+
+// closingQuote returns the index of the quote that ends s or -1. Only double quotes can be escaped.
+func closingQuote(s string, quote byte) int {
+	for i := 0; i < len(s); i++ {
+		if quote == '"' && s[i] == '\\' {
+			i++
+			continue
+		}
+		if s[i] == quote {
+			return i
+		}
+	}
+	return -1
+}
+
+func unescape(s string) string {
+	replacer := strings.NewReplacer(`\n`, "\n", `\r`, "\r", `\t`, "\t", `\"`, `"`, `\\`, `\`)
+	return replacer.Replace(s)
+}
+
+// RenderDotenv writes the entries as a dotenv file that ParseDotenv reads back unchanged.
+// Values with whitespace, quotes, `#`, `\` or line breaks are double quoted.
+func RenderDotenv(entries []DotenvEntry) string {
+	out := strings.Builder{}
+	for _, entry := range entries {
+		value := entry.Value
+		if value == "" || strings.ContainsAny(value, " \t\r\n\"'`#\\") {
+			replacer := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\r", `\r`, "\t", `\t`)
+			value = `"` + replacer.Replace(value) + `"`
+		}
+		fmt.Fprintf(&out, "%s=%s\n", entry.Key, value)
+	}
+	return out.String()
+}
+
+// FromDotenv turns the entries of a dotenv file into variables of the given type for the given targets.
+func FromDotenv(entries []DotenvEntry, envType string, target []string, gitBranch *string) []CreateOrUpdateEnv {
+	envs := []CreateOrUpdateEnv{}
+	for _, entry := range entries {
+		envs = append(envs, CreateOrUpdateEnv{
+			Type:      envType,
+			Key:       entry.Key,
+			Value:     entry.Value,
+			Target:    append([]string{}, target...),
+			GitBranch: gitBranch,
+		})
+	}
+	return envs
+}
@@ -0,0 +1,55 @@
+package env
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const testDotenv = `
+# Database
+export DATABASE_URL=postgres://localhost:5432/db
+PLAIN = value with spaces   # comment
+HASH=abc#def
+DOUBLE="line one\nline \"two\""
+SINGLE='literal \n $HOME'
+MULTI="-----BEGIN KEY-----
+abc
+-----END KEY-----" # trailing comment
+BACKTICK=` + "`it's`" + `
+EMPTY=
+PLAIN=overwritten
+`
+
+func TestParseDotenv(t *testing.T) {
+	entries, err := ParseDotenv(testDotenv)
+	require.NoError(t, err)
+	require.Equal(t, []DotenvEntry{
+		{Key: "DATABASE_URL", Value: "postgres://localhost:5432/db"},
+		{Key: "PLAIN", Value: "overwritten"},
+		{Key: "HASH", Value: "abc#def"},
+		{Key: "DOUBLE", Value: "line one\nline \"two\""},
+		{Key: "SINGLE", Value: `literal \n $HOME`},
+		{Key: "MULTI", Value: "-----BEGIN KEY-----\nabc\n-----END KEY-----"},
+		{Key: "BACKTICK", Value: "it's"},
+		{Key: "EMPTY", Value: ""},
+	}, entries)
+
+	rendered := RenderDotenv(entries)
+	parsed, err := ParseDotenv(rendered)
+	require.NoError(t, err)
+	require.Equal(t, entries, parsed, rendered)
+}
+
+func TestParseDotenvErrors(t *testing.T) {
+	invalid := map[string]string{
+		"missing separator": "KEY",
+		"invalid key":       "1KEY=value",
+		"unterminated":      `KEY="value`,
+		"trailing garbage":  `KEY="value" garbage`,
+	}
+	for name, content := range invalid {
+		_, err := ParseDotenv(content)
+		require.Error(t, err, name)
+	}
+}
@@ -1,16 +1,39 @@
 package env
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
-	"log"
 	"net/http"
+	"net/url"
 
 	"github.com/chronark/terraform-provider-vercel/pkg/vercel/httpApi"
+	"github.com/chronark/terraform-provider-vercel/pkg/vercel/pagination"
 )
 
+// The types of environment variables.
+const (
+	TypePlain  = "plain"
+	TypeSecret = "secret"
+	TypeSystem = "system"
+
+	// The value of encrypted variables is only returned encrypted.
+	TypeEncrypted = "encrypted"
+
+	// The value of sensitive variables can not be read at all after it was written.
+	TypeSensitive = "sensitive"
+)
+
+// Types lists all types of environment variables.
+var Types = []string{TypePlain, TypeSecret, TypeSystem, TypeEncrypted, TypeSensitive}
+
+// HidesValue reports whether vercel never returns the plain value of variables of this type.
+func HidesValue(envType string) bool {
+	return envType == TypeEncrypted || envType == TypeSensitive
+}
+
 type CreateOrUpdateEnv struct {
-	// The type can be `plain`, `secret`, or `system`.
+	// The type can be `plain`, `secret`, `system`, `encrypted` or `sensitive`.
 	Type string `json:"type"`
 
 	// The name of the environment variable.
@@ -19,6 +42,7 @@ type CreateOrUpdateEnv struct {
 	// If the type is `plain`, a string representing the value of the environment variable.
 	// If the type is `secret`, the secret ID of the secret attached to the environment variable.
 	// If the type is `system`, the name of the System Environment Variable.
+	// If the type is `encrypted` or `sensitive`, the plain value which vercel stores encrypted.
 	Value string `json:"value"`
 
 	// 	The target can be a list of `development`, `preview`, or `production`.
@@ -38,18 +62,43 @@ type Env struct {
 	ConfigurationID interface{} `json:"configurationId"`
 	UpdatedAt       int64       `json:"updatedAt"`
 	CreatedAt       int64       `json:"createdAt"`
+
+	// Whether the value was decrypted, only variables fetched with Get can be decrypted.
+	Decrypted bool `json:"decrypted"`
+}
+
+// HasValue reports whether the value of the variable was returned by vercel.
+func (e Env) HasValue() bool {
+	return !HidesValue(e.Type) || e.Decrypted
+}
+
+// String redacts the value, so the variable can be logged without leaking it.
+func (e CreateOrUpdateEnv) String() string {
+	return fmt.Sprintf("{Type:%s Key:%s Value:%s Target:%v}", e.Type, e.Key, redacted(e.Value), e.Target)
+}
+
+// String redacts the value, so the variable can be logged without leaking it.
+func (e Env) String() string {
+	return fmt.Sprintf("{Type:%s ID:%s Key:%s Value:%s Target:%v UpdatedAt:%d}", e.Type, e.ID, e.Key, redacted(e.Value), e.Target, e.UpdatedAt)
+}
+
+func redacted(value string) string {
+	if value == "" {
+		return ""
+	}
+	return "<redacted>"
 }
 
 type Handler struct {
 	Api httpApi.API
 }
 
-func (h *Handler) Create(projectID string, env CreateOrUpdateEnv, teamId string) (string, error) {
+func (h *Handler) Create(ctx context.Context, projectID string, env CreateOrUpdateEnv, teamId string) (string, error) {
 	url := fmt.Sprintf("/v6/projects/%s/env", projectID)
 	if teamId != "" {
 		url = fmt.Sprintf("%s/?teamId=%s", url, teamId)
 	}
-	res, err := h.Api.Request(http.MethodPost, url, env)
+	res, err := h.Api.RequestWithContext(ctx, http.MethodPost, url, env)
 	if err != nil {
 		return "", err
 	}
@@ -60,59 +109,84 @@ func (h *Handler) Create(projectID string, env CreateOrUpdateEnv, teamId string)
 	if err != nil {
 		return "", nil
 	}
-	log.Printf("%+v\n\n", createdEnv)
 
 	return createdEnv.ID, nil
 }
 
 // Read returns environment variables associated with a project
-func (h *Handler) Read(projectID string, teamId string) (envs []Env, err error) {
-	url := fmt.Sprintf("/v6/projects/%s/env", projectID)
+func (h *Handler) Read(ctx context.Context, projectID string, teamId string) (envs []Env, err error) {
+	query := url.Values{}
 	if teamId != "" {
-		url = fmt.Sprintf("%s/?teamId=%s", url, teamId)
-	}
-	res, err := h.Api.Request("GET", url, nil)
-	if err != nil {
-		return []Env{}, fmt.Errorf("Unable to fetch environment variables from vercel: %w", err)
+		query.Set("teamId", teamId)
 	}
-	defer res.Body.Close()
 
-	// EnvResponse is only a subset of available data but all we care about
 	// See https://vercel.com/docs/api#endpoints/projects/get-project-environment-variables
-	type EnvResponse struct {
-		Envs []Env `json:"envs"`
+	envs = []Env{}
+	pager := pagination.New(h.Api, fmt.Sprintf("/v6/projects/%s/env", projectID), query)
+	for pager.HasNext() {
+		var page struct {
+			Envs []Env `json:"envs"`
+		}
+		err = pager.Next(ctx, &page)
+		if err != nil {
+			return []Env{}, fmt.Errorf("Unable to fetch environment variables from vercel: %w", err)
+		}
+		envs = append(envs, page.Envs...)
+	}
+	return envs, nil
+}
+
+// Get returns a single variable of a project. With decrypt the value of `encrypted` variables is returned
+// in plain text, the value of `sensitive` variables is never returned.
+func (h *Handler) Get(ctx context.Context, projectID string, envID string, decrypt bool, teamId string) (Env, error) {
+	query := url.Values{}
+	if decrypt {
+		query.Set("decrypt", "true")
+	}
+	if teamId != "" {
+		query.Set("teamId", teamId)
+	}
+
+	path := fmt.Sprintf("/v1/projects/%s/env/%s", projectID, envID)
+	if len(query) > 0 {
+		path = fmt.Sprintf("%s?%s", path, query.Encode())
 	}
 
-	var envResponse EnvResponse
+	res, err := h.Api.RequestWithContext(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return Env{}, fmt.Errorf("Unable to fetch environment variable from vercel: %w", err)
+	}
+	defer res.Body.Close()
 
-	err = json.NewDecoder(res.Body).Decode(&envResponse)
+	var e Env
+	err = json.NewDecoder(res.Body).Decode(&e)
 	if err != nil {
-		return []Env{}, fmt.Errorf("Unable to unmarshal environment variables response: %w", err)
+		return Env{}, fmt.Errorf("Unable to unmarshal environment variable: %w", err)
 	}
-	log.Printf("%+v\n\n", envResponse)
-	return envResponse.Envs, nil
+	return e, nil
 }
-func (h *Handler) Update(projectID string, envID string, env CreateOrUpdateEnv, teamId string) error {
+
+func (h *Handler) Update(ctx context.Context, projectID string, envID string, env CreateOrUpdateEnv, teamId string) error {
 	url := fmt.Sprintf("/v6/projects/%s/env/%s", projectID, envID)
 	if teamId != "" {
 		url = fmt.Sprintf("%s/?teamId=%s", url, teamId)
 	}
 
-	res, err := h.Api.Request("PATCH", url, env)
+	res, err := h.Api.RequestWithContext(ctx, "PATCH", url, env)
 	if err != nil {
 		return fmt.Errorf("Unable to update env: %w", err)
 	}
 	defer res.Body.Close()
 	return nil
 }
-func (h *Handler) Delete(projectID, envKey string, teamId string) error {
+func (h *Handler) Delete(ctx context.Context, projectID, envKey string, teamId string) error {
 	url := fmt.Sprintf("/v8/projects/%s/env/%s", projectID, envKey)
 
 	if teamId != "" {
 		url = fmt.Sprintf("%s/?teamId=%s", url, teamId)
 	}
 
-	res, err := h.Api.Request("DELETE", url, nil)
+	res, err := h.Api.RequestWithContext(ctx, "DELETE", url, nil)
 
 	if err != nil {
 		return fmt.Errorf("unable to delete env: %w", err)
@@ -0,0 +1,70 @@
+package env
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStringRedactsValue(t *testing.T) {
+	logged := []string{
+		fmt.Sprintf("%+v", Env{Type: TypeEncrypted, Key: "TOKEN", Value: "hunter2"}),
+		fmt.Sprintf("%v", []Env{{Type: TypePlain, Key: "TOKEN", Value: "hunter2"}}),
+		fmt.Sprintf("%+v", CreateOrUpdateEnv{Type: TypeSensitive, Key: "TOKEN", Value: "hunter2"}),
+	}
+	for _, line := range logged {
+		require.False(t, strings.Contains(line, "hunter2"), line)
+		require.True(t, strings.Contains(line, "TOKEN"), line)
+	}
+}
+
+func TestDiff(t *testing.T) {
+	preview := "staging"
+	existing := []Env{
+		{ID: "env_keep", Type: TypePlain, Key: "KEEP", Value: "same", Target: []string{"production", "preview"}},
+		{ID: "env_value", Type: TypePlain, Key: "VALUE", Value: "old", Target: []string{"production"}},
+		{ID: "env_target", Type: TypePlain, Key: "TARGET", Value: "same", Target: []string{"production"}},
+		{ID: "env_hidden", Type: TypeSensitive, Key: "HIDDEN", Target: []string{"production"}},
+		{ID: "env_unmanaged", Type: TypePlain, Key: "UNMANAGED", Value: "x", Target: []string{"production"}},
+	}
+	desired := []CreateOrUpdateEnv{
+		{Type: TypePlain, Key: "KEEP", Value: "same", Target: []string{"preview", "production"}},
+		{Type: TypePlain, Key: "VALUE", Value: "new", Target: []string{"production"}},
+		{Type: TypePlain, Key: "TARGET", Value: "same", Target: []string{"preview"}, GitBranch: &preview},
+		{Type: TypeSensitive, Key: "HIDDEN", Value: "hunter2", Target: []string{"production"}},
+		{Type: TypeEncrypted, Key: "NEW", Value: "new", Target: []string{"production"}},
+	}
+
+	changes := Diff(desired, existing)
+	require.Equal(t, []CreateOrUpdateEnv{desired[4]}, changes.Create)
+	require.Equal(t, []EnvUpdate{
+		{ID: "env_value", Env: desired[1]},
+		{ID: "env_hidden", Env: desired[3]},
+		{ID: "env_target", Env: desired[2]},
+	}, changes.Update)
+	require.Equal(t, []Env{existing[4]}, changes.Delete)
+
+	existing[3].Value = "hunter2"
+	require.True(t, Diff(desired[:1], existing[:1]).Empty())
+	require.True(t, Diff(desired[3:4], existing[3:4]).Empty(), "hidden values are compared with the last known value")
+}
+
+func TestDiffManagedKeepsUnmanagedVariables(t *testing.T) {
+	existing := []Env{
+		{ID: "env_managed", Type: TypePlain, Key: "MANAGED", Value: "same", Target: []string{"production"}},
+		{ID: "env_preview", Type: TypePlain, Key: "TOKEN", Value: "preview", Target: []string{"preview"}},
+		{ID: "env_other", Type: TypePlain, Key: "OTHER", Value: "x", Target: []string{"production"}},
+	}
+	desired := []CreateOrUpdateEnv{
+		{Type: TypePlain, Key: "MANAGED", Value: "same", Target: []string{"preview"}},
+		{Type: TypePlain, Key: "TOKEN", Value: "production", Target: []string{"production"}},
+	}
+	managed := func(e Env) bool { return e.ID == "env_managed" }
+
+	changes := DiffManaged(desired, existing, managed)
+	require.Equal(t, []EnvUpdate{{ID: "env_managed", Env: desired[0]}}, changes.Update)
+	require.Equal(t, []CreateOrUpdateEnv{desired[1]}, changes.Create, "an unmanaged variable with the same key is not moved")
+	require.Empty(t, changes.Delete)
+}
@@ -0,0 +1,211 @@
+package fake
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/chronark/terraform-provider-vercel/pkg/vercel/deployment"
+)
+
+func (s *Server) registerDeploymentRoutes() {
+	s.handle(http.MethodPost, "files", s.uploadFile)
+
+	s.handle(http.MethodPost, "deployments", s.createDeployment)
+	s.handle(http.MethodGet, "deployments", s.listDeployments)
+	s.handle(http.MethodGet, "deployments/*", s.readDeployment)
+	s.handle(http.MethodDelete, "deployments/*", s.deleteDeployment)
+}
+
+func (s *Server) uploadFile(w http.ResponseWriter, r *http.Request, params []string) {
+	content, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "bad_request", err.Error())
+		return
+	}
+
+	digest := r.Header.Get("x-vercel-digest")
+	if digest != deployment.Sha1(content) {
+		writeError(w, http.StatusBadRequest, "invalid_digest", "The x-vercel-digest header does not match the content")
+		return
+	}
+	s.files[digest] = content
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{})
+}
+
+// findDeployment looks up a deployment by id or url within the team of the request.
+func (s *Server) findDeployment(r *http.Request, idOrUrl string) *storedDeployment {
+	teamID := r.URL.Query().Get("teamId")
+	for _, stored := range s.deployments {
+		if stored.teamID == teamID && (stored.deployment.ID == idOrUrl || stored.deployment.URL == idOrUrl) {
+			return stored
+		}
+	}
+	return nil
+}
+
+func (s *Server) createDeployment(w http.ResponseWriter, r *http.Request, params []string) {
+	var create deployment.CreateDeployment
+	if !decode(w, r, &create) {
+		return
+	}
+	if create.Name == "" {
+		writeError(w, http.StatusBadRequest, "bad_request", "A deployment name is required")
+		return
+	}
+
+	missing := []string{}
+	for _, f := range create.Files {
+		if _, ok := s.files[f.Sha]; !ok {
+			missing = append(missing, f.Sha)
+		}
+	}
+	if len(missing) > 0 {
+		writeJSON(w, http.StatusBadRequest, map[string]interface{}{
+			"error": map[string]interface{}{
+				"code":    "missing_files",
+				"message": "Missing files",
+				"missing": missing,
+			},
+		})
+		return
+	}
+
+	teamID := r.URL.Query().Get("teamId")
+	// Like vercel, deployments without a project end up in a project named after the deployment
+	var p *storedProject
+	if create.Project != "" {
+		p = s.findProject(r, create.Project)
+		if p == nil {
+			notFound(w, "Project %s not found", create.Project)
+			return
+		}
+	} else if p = s.findProject(r, create.Name); p == nil {
+		p = &storedProject{teamID: teamID, seq: s.nextID + 1}
+		p.project.ID = s.id("prj")
+		p.project.Name = create.Name
+		p.project.AccountID = teamID
+		if teamID == "" {
+			p.project.AccountID = s.user.UID
+		}
+		p.project.CreatedAt = now()
+		p.project.UpdatedAt = p.project.CreatedAt
+		s.projects[p.project.ID] = p
+	}
+	projectID := p.project.ID
+
+	id := s.id("dpl")
+	stored := &storedDeployment{
+		teamID: teamID,
+		seq:    s.nextID,
+		deployment: deployment.Deployment{
+			ID:         id,
+			URL:        fmt.Sprintf("%s-%s.vercel.app", create.Name, id),
+			Name:       create.Name,
+			ProjectID:  projectID,
+			OwnerID:    teamID,
+			Target:     create.Target,
+			ReadyState: deployment.StateQueued,
+			Meta:       create.Meta,
+			CreatedAt:  now(),
+		},
+	}
+	if teamID == "" {
+		stored.deployment.OwnerID = s.user.UID
+	}
+	stored.deployment.Creator.UID = s.user.UID
+	stored.deployment.Creator.Email = s.user.Email
+	stored.deployment.Creator.Username = s.user.Username
+
+	// Production deployments are served by all domains of their project
+	stored.deployment.Alias = []string{}
+	if create.Target == "production" {
+		for _, d := range s.projectDomains[projectID] {
+			stored.deployment.Alias = append(stored.deployment.Alias, d.Name)
+		}
+	}
+	s.deployments[id] = stored
+
+	writeJSON(w, http.StatusOK, stored.deployment)
+}
+
+// listDeployments answers in the shorter format of the list endpoint, newest deployments first.
+func (s *Server) listDeployments(w http.ResponseWriter, r *http.Request, params []string) {
+	query := r.URL.Query()
+
+	var matches []*storedDeployment
+	for _, stored := range s.deployments {
+		d := stored.deployment
+		if stored.teamID != query.Get("teamId") ||
+			(query.Get("projectId") != "" && d.ProjectID != query.Get("projectId")) ||
+			(query.Get("target") != "" && d.Target != query.Get("target")) ||
+			(query.Get("state") != "" && !contains(strings.Split(query.Get("state"), ","), d.ReadyState)) {
+			continue
+		}
+		matches = append(matches, stored)
+	}
+	sort.Slice(matches, func(i, j int) bool { return matches[i].seq > matches[j].seq })
+
+	start, end, page := paginate(r, len(matches))
+
+	deployments := []map[string]interface{}{}
+	for _, stored := range matches[start:end] {
+		d := stored.deployment
+		deployments = append(deployments, map[string]interface{}{
+			"uid":       d.ID,
+			"name":      d.Name,
+			"url":       d.URL,
+			"projectId": d.ProjectID,
+			"created":   d.CreatedAt,
+			"state":     d.ReadyState,
+			"target":    d.Target,
+			"meta":      d.Meta,
+			"creator":   d.Creator,
+		})
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"deployments": deployments,
+		"pagination":  page,
+	})
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// Deployments are queued when they are created and finish building as soon as they are read.
+func (s *Server) readDeployment(w http.ResponseWriter, r *http.Request, params []string) {
+	stored := s.findDeployment(r, params[0])
+	if stored == nil {
+		notFound(w, "Deployment %s not found", params[0])
+		return
+	}
+
+	if stored.deployment.ReadyState == deployment.StateQueued {
+		stored.deployment.ReadyState = deployment.StateReady
+		stored.deployment.Ready = now()
+	}
+
+	writeJSON(w, http.StatusOK, stored.deployment)
+}
+
+func (s *Server) deleteDeployment(w http.ResponseWriter, r *http.Request, params []string) {
+	stored := s.findDeployment(r, params[0])
+	if stored == nil {
+		notFound(w, "Deployment %s not found", params[0])
+		return
+	}
+
+	delete(s.deployments, stored.deployment.ID)
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{"uid": stored.deployment.ID, "state": "DELETED"})
+}
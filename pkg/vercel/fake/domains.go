@@ -0,0 +1,384 @@
+package fake
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/chronark/terraform-provider-vercel/pkg/vercel/dns"
+	"github.com/chronark/terraform-provider-vercel/pkg/vercel/domain"
+)
+
+// The nameservers vercel asks domain owners to delegate to.
+var intendedNameservers = []string{"ns1.vercel-dns.com", "ns2.vercel-dns.com"}
+
+func (s *Server) registerDomainRoutes() {
+	// Registered before `domains/*`, which would match them as well
+	s.handle(http.MethodGet, "domains/price", s.domainPrice)
+	s.handle(http.MethodPost, "domains/buy", s.buyDomain)
+
+	s.handle(http.MethodPost, "domains", s.createDomain)
+	s.handle(http.MethodGet, "domains", s.listDomains)
+	s.handle(http.MethodGet, "domains/*", s.readDomain)
+	s.handle(http.MethodPatch, "domains/*", s.updateDomain)
+	s.handle(http.MethodDelete, "domains/*", s.deleteDomain)
+	s.handle(http.MethodPost, "domains/*/verify", s.verifyDomain)
+
+	s.handle(http.MethodPost, "domains/*/records", s.createRecord)
+	s.handle(http.MethodGet, "domains/*/records", s.listRecords)
+	s.handle(http.MethodDelete, "domains/*/records/*", s.deleteRecord)
+	s.handle(http.MethodPatch, "domains/records/*", s.updateRecord)
+}
+
+// SetNameservers changes the nameservers a domain is delegated to, like the owner would at the registrar.
+func (s *Server) SetNameservers(domainName string, nameservers []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if stored, ok := s.domains[domainName]; ok {
+		stored.domain.Nameservers = nameservers
+	}
+}
+
+// findDomain looks up a domain by name within the team of the request.
+func (s *Server) findDomain(r *http.Request, name string) *storedDomain {
+	stored, ok := s.domains[name]
+	if !ok || stored.teamID != r.URL.Query().Get("teamId") {
+		return nil
+	}
+	return stored
+}
+
+func (s *Server) createDomain(w http.ResponseWriter, r *http.Request, params []string) {
+	var create domain.CreateDomain
+	if !decode(w, r, &create) {
+		return
+	}
+	if _, exists := s.domains[create.Name]; exists {
+		writeError(w, http.StatusConflict, "conflict", "The domain is already registered")
+		return
+	}
+
+	switch create.Method {
+	case "", domain.MethodAdd:
+		stored := s.addDomain(r, create.Name)
+		writeJSON(w, http.StatusOK, map[string]interface{}{"domain": stored.domain})
+	case domain.MethodTransferIn:
+		if create.AuthCode == "" {
+			writeError(w, http.StatusBadRequest, "missing_auth_code", "An auth code is required to transfer a domain")
+			return
+		}
+		if !checkPrice(w, create.Name, create.ExpectedPrice) {
+			return
+		}
+		stored := s.addDomain(r, create.Name)
+		stored.domain.ServiceType = domain.ServiceTypeRegistered
+		stored.domain.TransferStartedAt = now()
+		stored.domain.Renew = true
+		writeJSON(w, http.StatusOK, map[string]interface{}{"domain": stored.domain})
+	default:
+		writeError(w, http.StatusBadRequest, "bad_request", fmt.Sprintf("Unsupported method %q", create.Method))
+	}
+}
+
+// addDomain stores an unverified domain of the team that is registered elsewhere.
+func (s *Server) addDomain(r *http.Request, name string) *storedDomain {
+	stored := &storedDomain{
+		teamID: r.URL.Query().Get("teamId"),
+		domain: domain.Domain{
+			ID:                  s.id("dom"),
+			Name:                name,
+			ServiceType:         "external",
+			CreatedAt:           now(),
+			Nameservers:         []string{},
+			IntendedNameservers: intendedNameservers,
+		},
+	}
+	stored.domain.Creator.ID = s.user.UID
+	stored.domain.Creator.Username = s.user.Username
+	stored.domain.Creator.Email = s.user.Email
+	s.domains[name] = stored
+	return stored
+}
+
+// domainPrice charges 20 dollars per year for .com domains and 30 for every other domain.
+func domainPrice(name string) int {
+	if strings.HasSuffix(name, ".com") {
+		return 20
+	}
+	return 30
+}
+
+// checkPrice fails the request like vercel does if the expected price is not the actual price.
+func checkPrice(w http.ResponseWriter, name string, expectedPrice int) bool {
+	if price := domainPrice(name); expectedPrice != price {
+		writeError(w, http.StatusBadRequest, "price_mismatch", fmt.Sprintf("The price of %s is %d, not %d", name, price, expectedPrice))
+		return false
+	}
+	return true
+}
+
+func (s *Server) domainPrice(w http.ResponseWriter, r *http.Request, params []string) {
+	name := r.URL.Query().Get("name")
+	if name == "" {
+		writeError(w, http.StatusBadRequest, "bad_request", "A domain name is required")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, domain.Price{Price: domainPrice(name), Period: 1})
+}
+
+// buyDomain registers the domain with vercel, so it is verified and delegated right away.
+func (s *Server) buyDomain(w http.ResponseWriter, r *http.Request, params []string) {
+	var buy domain.BuyDomain
+	if !decode(w, r, &buy) {
+		return
+	}
+	if _, exists := s.domains[buy.Name]; exists {
+		writeError(w, http.StatusConflict, "not_available", "The domain is not available")
+		return
+	}
+	if !checkPrice(w, buy.Name, buy.ExpectedPrice) {
+		return
+	}
+
+	stored := s.addDomain(r, buy.Name)
+	stored.domain.ServiceType = domain.ServiceTypeRegistered
+	stored.domain.Registrar = "Vercel"
+	stored.domain.BoughtAt = stored.domain.CreatedAt
+	stored.domain.ExpiresAt = stored.domain.CreatedAt + int64(365*24*time.Hour/time.Millisecond)
+	stored.domain.Renew = buy.Renew
+	stored.domain.Verified = true
+	stored.domain.NsVerifiedAt = stored.domain.CreatedAt
+	stored.domain.Nameservers = intendedNameservers
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"domain": map[string]interface{}{
+			"uid":      stored.domain.ID,
+			"ns":       stored.domain.Nameservers,
+			"verified": true,
+			"created":  stored.domain.CreatedAt,
+			"pending":  false,
+		},
+	})
+}
+
+// updateDomain changes the renewal of domains registered through vercel.
+func (s *Server) updateDomain(w http.ResponseWriter, r *http.Request, params []string) {
+	stored := s.findDomain(r, params[0])
+	if stored == nil {
+		notFound(w, "Domain %s not found", params[0])
+		return
+	}
+
+	var update struct {
+		Op    string `json:"op"`
+		Renew *bool  `json:"renew"`
+	}
+	if !decode(w, r, &update) {
+		return
+	}
+	if update.Op != "update" {
+		writeError(w, http.StatusBadRequest, "bad_request", fmt.Sprintf("Unsupported op %q", update.Op))
+		return
+	}
+	if update.Renew != nil {
+		if stored.domain.ServiceType != domain.ServiceTypeRegistered {
+			writeError(w, http.StatusBadRequest, "not_registered", fmt.Sprintf("Domain %s is not registered with vercel", params[0]))
+			return
+		}
+		stored.domain.Renew = *update.Renew
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{"name": stored.domain.Name})
+}
+
+// listDomains returns the domains of the team sorted by name.
+func (s *Server) listDomains(w http.ResponseWriter, r *http.Request, params []string) {
+	all := []domain.Domain{}
+	for _, stored := range s.domains {
+		if stored.teamID == r.URL.Query().Get("teamId") {
+			all = append(all, stored.domain)
+		}
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i].Name < all[j].Name })
+
+	start, end, page := paginate(r, len(all))
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"domains":    all[start:end],
+		"pagination": page,
+	})
+}
+
+func (s *Server) readDomain(w http.ResponseWriter, r *http.Request, params []string) {
+	stored := s.findDomain(r, params[0])
+	if stored == nil {
+		notFound(w, "Domain %s not found", params[0])
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{"domain": stored.domain})
+}
+
+// verifyDomain verifies the domain once its nameservers point to vercel.
+func (s *Server) verifyDomain(w http.ResponseWriter, r *http.Request, params []string) {
+	stored := s.findDomain(r, params[0])
+	if stored == nil {
+		notFound(w, "Domain %s not found", params[0])
+		return
+	}
+
+	if !stored.domain.Verified {
+		if !reflect.DeepEqual(stored.domain.Nameservers, stored.domain.IntendedNameservers) {
+			writeError(w, http.StatusBadRequest, "verification_failed", fmt.Sprintf("Domain %s is not delegated to %s", params[0], strings.Join(stored.domain.IntendedNameservers, ", ")))
+			return
+		}
+		stored.domain.Verified = true
+		stored.domain.NsVerifiedAt = now()
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{"domain": stored.domain})
+}
+
+func (s *Server) deleteDomain(w http.ResponseWriter, r *http.Request, params []string) {
+	stored := s.findDomain(r, params[0])
+	if stored == nil {
+		notFound(w, "Domain %s not found", params[0])
+		return
+	}
+
+	delete(s.domains, params[0])
+	delete(s.records, params[0])
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{"uid": stored.domain.ID})
+}
+
+// findRecord returns the index of the record in the domain's records or -1.
+func (s *Server) findRecord(domainName, recordID string) int {
+	for i, record := range s.records[domainName] {
+		if record.Id == recordID {
+			return i
+		}
+	}
+	return -1
+}
+
+func (s *Server) createRecord(w http.ResponseWriter, r *http.Request, params []string) {
+	if s.findDomain(r, params[0]) == nil {
+		notFound(w, "Domain %s not found", params[0])
+		return
+	}
+
+	var create dns.CreateRecord
+	if !decode(w, r, &create) {
+		return
+	}
+
+	if err := create.Validate(); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_value", err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{"uid": s.addRecord(params[0], create)})
+}
+
+// addRecord stores a new record of the domain and returns its id.
+func (s *Server) addRecord(domainName string, create dns.CreateRecord) string {
+	record := dns.Record{
+		Id:        s.id("rec"),
+		Type:      create.Type,
+		Creator:   s.user.UID,
+		CreatedAt: int(now()),
+	}
+	record.Created = record.CreatedAt
+	applyRecord(&record, dns.UpdateRecord{
+		Name:       create.Name,
+		Value:      create.Value,
+		TTL:        create.TTL,
+		MXPriority: create.MXPriority,
+		SRV:        create.SRV,
+		Comment:    create.Comment,
+	})
+	s.records[domainName] = append(s.records[domainName], record)
+	return record.Id
+}
+
+func (s *Server) listRecords(w http.ResponseWriter, r *http.Request, params []string) {
+	if s.findDomain(r, params[0]) == nil {
+		notFound(w, "Domain %s not found", params[0])
+		return
+	}
+
+	all := s.records[params[0]]
+	start, end, page := paginate(r, len(all))
+	records := append([]dns.Record{}, all[start:end]...)
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"records":    records,
+		"pagination": page,
+	})
+}
+
+// applyRecord stores the record the way vercel returns it, SRV records keep their priority separately.
+func applyRecord(record *dns.Record, update dns.UpdateRecord) {
+	record.Name = update.Name
+	record.Value = update.Value
+	record.TTL = update.TTL
+	record.Comment = update.Comment
+	if update.MXPriority != nil {
+		record.MXPriority = *update.MXPriority
+	}
+	if update.SRV != nil {
+		record.Priority = update.SRV.Priority
+		record.Value = fmt.Sprintf("%d %d %s", update.SRV.Weight, update.SRV.Port, update.SRV.Target)
+	}
+	record.UpdatedAt = int(now())
+	record.Updated = record.UpdatedAt
+}
+
+// updateRecord changes a record that is only identified by its id, so all domains of the team are searched.
+func (s *Server) updateRecord(w http.ResponseWriter, r *http.Request, params []string) {
+	var record *dns.Record
+	for name, stored := range s.domains {
+		if stored.teamID != r.URL.Query().Get("teamId") {
+			continue
+		}
+		if i := s.findRecord(name, params[0]); i >= 0 {
+			record = &s.records[name][i]
+		}
+	}
+	if record == nil {
+		notFound(w, "Record %s not found", params[0])
+		return
+	}
+
+	var update dns.UpdateRecord
+	if !decode(w, r, &update) {
+		return
+	}
+	applyRecord(record, update)
+
+	writeJSON(w, http.StatusOK, record)
+}
+
+func (s *Server) deleteRecord(w http.ResponseWriter, r *http.Request, params []string) {
+	if s.findDomain(r, params[0]) == nil {
+		notFound(w, "Domain %s not found", params[0])
+		return
+	}
+
+	i := s.findRecord(params[0], params[1])
+	if i < 0 {
+		notFound(w, "Record %s not found", params[1])
+		return
+	}
+
+	records := s.records[params[0]]
+	s.records[params[0]] = append(records[:i], records[i+1:]...)
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{})
+}
@@ -0,0 +1,213 @@
+package fake
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/chronark/terraform-provider-vercel/pkg/vercel/env"
+)
+
+func (s *Server) registerEnvRoutes() {
+	s.handle(http.MethodPost, "projects/*/env", s.createEnv)
+	s.handle(http.MethodGet, "projects/*/env", s.listEnvs)
+	s.handle(http.MethodGet, "projects/*/env/*", s.readEnv)
+	s.handle(http.MethodPatch, "projects/*/env/*", s.updateEnv)
+	s.handle(http.MethodDelete, "projects/*/env/*", s.deleteEnv)
+}
+
+// findEnv returns the index of the variable in the project's variables or -1.
+func (s *Server) findEnv(projectID, envID string) int {
+	for i, e := range s.envs[projectID] {
+		if e.ID == envID {
+			return i
+		}
+	}
+	return -1
+}
+
+func applyEnv(e *env.Env, update env.CreateOrUpdateEnv) {
+	e.Type = update.Type
+	e.Key = update.Key
+	e.Value = update.Value
+	e.Target = update.Target
+	e.GitBranch = ""
+	if update.GitBranch != nil {
+		e.GitBranch = *update.GitBranch
+	}
+	e.UpdatedAt = now()
+}
+
+// hideValue returns the variable the way vercel returns it, without the value of encrypted and sensitive variables.
+func hideValue(e env.Env) env.Env {
+	if env.HidesValue(e.Type) {
+		e.Value = ""
+	}
+	return e
+}
+
+// createEnv creates a single variable or, like the v10 endpoint, every variable of an array.
+func (s *Server) createEnv(w http.ResponseWriter, r *http.Request, params []string) {
+	p := s.findProject(r, params[0])
+	if p == nil {
+		notFound(w, "Project %s not found", params[0])
+		return
+	}
+
+	var body json.RawMessage
+	if !decode(w, r, &body) {
+		return
+	}
+
+	if !bytes.HasPrefix(bytes.TrimSpace(body), []byte("[")) {
+		var create env.CreateOrUpdateEnv
+		if err := json.Unmarshal(body, &create); err != nil {
+			writeError(w, http.StatusBadRequest, "bad_request", err.Error())
+			return
+		}
+		if s.envConflicts(p.project.ID, create) {
+			writeError(w, http.StatusBadRequest, "ENV_ALREADY_EXISTS", fmt.Sprintf("A variable with the name %s already exists for the given target", create.Key))
+			return
+		}
+		writeJSON(w, http.StatusOK, hideValue(s.addEnv(p.project.ID, create)))
+		return
+	}
+
+	var creates []env.CreateOrUpdateEnv
+	if err := json.Unmarshal(body, &creates); err != nil {
+		writeError(w, http.StatusBadRequest, "bad_request", err.Error())
+		return
+	}
+
+	created := []env.Env{}
+	failed := []map[string]interface{}{}
+	for _, create := range creates {
+		if s.envConflicts(p.project.ID, create) {
+			failed = append(failed, map[string]interface{}{"error": map[string]string{
+				"code":    "ENV_ALREADY_EXISTS",
+				"message": "A variable with the name already exists for the given target",
+				"key":     create.Key,
+			}})
+			continue
+		}
+		created = append(created, hideValue(s.addEnv(p.project.ID, create)))
+	}
+
+	writeJSON(w, http.StatusCreated, map[string]interface{}{"created": created, "failed": failed})
+}
+
+func (s *Server) addEnv(projectID string, create env.CreateOrUpdateEnv) env.Env {
+	e := env.Env{ID: s.id("env"), CreatedAt: now()}
+	applyEnv(&e, create)
+	s.envs[projectID] = append(s.envs[projectID], e)
+	return e
+}
+
+// envConflicts reports whether a variable with the same key already exists for one of the targets and the git branch.
+func (s *Server) envConflicts(projectID string, create env.CreateOrUpdateEnv) bool {
+	branch := ""
+	if create.GitBranch != nil {
+		branch = *create.GitBranch
+	}
+	for _, e := range s.envs[projectID] {
+		if e.Key != create.Key || e.GitBranch != branch {
+			continue
+		}
+		for _, have := range e.Target {
+			for _, want := range create.Target {
+				if have == want {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+func (s *Server) listEnvs(w http.ResponseWriter, r *http.Request, params []string) {
+	p := s.findProject(r, params[0])
+	if p == nil {
+		notFound(w, "Project %s not found", params[0])
+		return
+	}
+
+	all := s.envs[p.project.ID]
+	start, end, page := paginate(r, len(all))
+	envs := []env.Env{}
+	for _, e := range all[start:end] {
+		envs = append(envs, hideValue(e))
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"envs":       envs,
+		"pagination": page,
+	})
+}
+
+// readEnv returns a single variable, `decrypt=true` reveals the value of encrypted variables.
+func (s *Server) readEnv(w http.ResponseWriter, r *http.Request, params []string) {
+	p := s.findProject(r, params[0])
+	if p == nil {
+		notFound(w, "Project %s not found", params[0])
+		return
+	}
+
+	i := s.findEnv(p.project.ID, params[1])
+	if i < 0 {
+		notFound(w, "Environment variable %s not found", params[1])
+		return
+	}
+
+	e := s.envs[p.project.ID][i]
+	if e.Type == env.TypeEncrypted && r.URL.Query().Get("decrypt") == "true" {
+		e.Decrypted = true
+		writeJSON(w, http.StatusOK, e)
+		return
+	}
+	writeJSON(w, http.StatusOK, hideValue(e))
+}
+
+func (s *Server) updateEnv(w http.ResponseWriter, r *http.Request, params []string) {
+	p := s.findProject(r, params[0])
+	if p == nil {
+		notFound(w, "Project %s not found", params[0])
+		return
+	}
+
+	i := s.findEnv(p.project.ID, params[1])
+	if i < 0 {
+		notFound(w, "Environment variable %s not found", params[1])
+		return
+	}
+
+	var update env.CreateOrUpdateEnv
+	if !decode(w, r, &update) {
+		return
+	}
+
+	e := &s.envs[p.project.ID][i]
+	applyEnv(e, update)
+
+	writeJSON(w, http.StatusOK, hideValue(*e))
+}
+
+func (s *Server) deleteEnv(w http.ResponseWriter, r *http.Request, params []string) {
+	p := s.findProject(r, params[0])
+	if p == nil {
+		notFound(w, "Project %s not found", params[0])
+		return
+	}
+
+	i := s.findEnv(p.project.ID, params[1])
+	if i < 0 {
+		notFound(w, "Environment variable %s not found", params[1])
+		return
+	}
+
+	envs := s.envs[p.project.ID]
+	deleted := envs[i]
+	s.envs[p.project.ID] = append(envs[:i], envs[i+1:]...)
+
+	writeJSON(w, http.StatusOK, hideValue(deleted))
+}
@@ -0,0 +1,536 @@
+package fake
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/chronark/terraform-provider-vercel/pkg/vercel/alias"
+	"github.com/chronark/terraform-provider-vercel/pkg/vercel/dns"
+	"github.com/chronark/terraform-provider-vercel/pkg/vercel/project"
+	pdomain "github.com/chronark/terraform-provider-vercel/pkg/vercel/project_domain"
+)
+
+func (s *Server) registerProjectRoutes() {
+	s.handle(http.MethodPost, "projects", s.createProject)
+	s.handle(http.MethodGet, "projects", s.listProjects)
+	s.handle(http.MethodGet, "projects/*", s.readProject)
+	s.handle(http.MethodPatch, "projects/*", s.updateProject)
+	s.handle(http.MethodDelete, "projects/*", s.deleteProject)
+	s.handle(http.MethodPatch, "projects/*/branch", s.updateProjectBranch)
+	s.handle(http.MethodPost, "projects/*/link", s.linkProject)
+	s.handle(http.MethodDelete, "projects/*/link", s.unlinkProject)
+
+	s.handle(http.MethodPost, "projects/*/alias", s.createAlias)
+	s.handle(http.MethodPatch, "projects/*/alias", s.updateAlias)
+	s.handle(http.MethodDelete, "projects/*/alias", s.deleteAlias)
+
+	s.handle(http.MethodPost, "projects/*/domains", s.createProjectDomain)
+	s.handle(http.MethodGet, "projects/*/domains", s.listProjectDomains)
+	s.handle(http.MethodGet, "projects/*/domains/*", s.readProjectDomain)
+	s.handle(http.MethodPatch, "projects/*/domains/*", s.updateProjectDomain)
+	s.handle(http.MethodDelete, "projects/*/domains/*", s.deleteProjectDomain)
+	s.handle(http.MethodPost, "projects/*/domains/*/verify", s.verifyProjectDomain)
+}
+
+// findProject looks up a project by id or name within the team of the request.
+func (s *Server) findProject(r *http.Request, idOrName string) *storedProject {
+	teamID := r.URL.Query().Get("teamId")
+	for _, p := range s.projects {
+		if p.teamID == teamID && (p.project.ID == idOrName || p.project.Name == idOrName) {
+			return p
+		}
+	}
+	return nil
+}
+
+// render returns the project the way the api returns it, including its domains and environment variables.
+func (s *Server) render(p *storedProject) project.Project {
+	rendered := p.project
+
+	rendered.Aliases = []alias.Alias{}
+	for _, d := range s.projectDomains[p.project.ID] {
+		rendered.Aliases = append(rendered.Aliases, alias.Alias{
+			Domain:             d.Name,
+			Redirect:           d.Redirect,
+			RedirectStatusCode: d.RedirectStatusCode,
+			GitBranch:          d.GitBranch,
+			ProjectId:          d.ProjectID,
+			CreatedAt:          d.CreatedAt,
+		})
+	}
+	rendered.Env = append(rendered.Env, s.envs[p.project.ID]...)
+
+	return rendered
+}
+
+func (s *Server) createProject(w http.ResponseWriter, r *http.Request, params []string) {
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "bad_request", err.Error())
+		return
+	}
+
+	var create project.CreateProject
+	if err := patch(&create, body); err != nil || create.Name == "" {
+		writeError(w, http.StatusBadRequest, "bad_request", "A project name is required")
+		return
+	}
+	if s.findProject(r, create.Name) != nil {
+		writeError(w, http.StatusConflict, "conflict", "A project with this name already exists")
+		return
+	}
+
+	teamID := r.URL.Query().Get("teamId")
+	p := &storedProject{teamID: teamID, seq: s.nextID + 1}
+	p.project.GitForkProtection = true
+	p.project.AutoExposeSystemEnvs = true
+	p.project.SourceFilesOutsideRootDirectory = true
+	if err := patch(&p.project, body); err != nil {
+		writeError(w, http.StatusBadRequest, "bad_request", err.Error())
+		return
+	}
+
+	// Settings of the resource config that were left out get their defaults
+	config := &p.project.ResourceConfig
+	if config.FunctionDefaultMemoryType == "" {
+		config.FunctionDefaultMemoryType = project.MemoryTypeStandard
+	}
+	if config.FunctionDefaultTimeout == 0 {
+		config.FunctionDefaultTimeout = 10
+	}
+	if len(config.FunctionDefaultRegions) == 0 {
+		config.FunctionDefaultRegions = []string{"iad1"}
+	}
+
+	p.project.ID = s.id("prj")
+	p.project.AccountID = teamID
+	if teamID == "" {
+		p.project.AccountID = s.user.UID
+	}
+	p.project.CreatedAt = now()
+	p.project.UpdatedAt = p.project.CreatedAt
+
+	if create.GitRepository != nil {
+		link(p, *create.GitRepository)
+	}
+
+	s.projects[p.project.ID] = p
+
+	writeJSON(w, http.StatusOK, s.render(p))
+}
+
+// link connects the project to a repository whose default branch is always `main`.
+func link(p *storedProject, repo project.GitRepository) {
+	p.project.Link = project.Project{}.Link
+	p.project.Link.Type = repo.Type
+	p.project.Link.Org, p.project.Link.Repo = splitRepo(repo.Repo)
+	p.project.Link.ProductionBranch = "main"
+	p.project.Link.CreatedAt = now()
+	p.project.Link.UpdatedAt = p.project.Link.CreatedAt
+}
+
+func splitRepo(repo string) (string, string) {
+	parts := strings.SplitN(repo, "/", 2)
+	if len(parts) == 1 {
+		return "", parts[0]
+	}
+	return parts[0], parts[1]
+}
+
+// listProjects returns the projects of the team, most recently created first.
+func (s *Server) listProjects(w http.ResponseWriter, r *http.Request, params []string) {
+	teamID := r.URL.Query().Get("teamId")
+	search := r.URL.Query().Get("search")
+
+	var matches []*storedProject
+	for _, p := range s.projects {
+		if p.teamID == teamID && strings.Contains(p.project.Name, search) {
+			matches = append(matches, p)
+		}
+	}
+	sort.Slice(matches, func(i, j int) bool { return matches[i].seq > matches[j].seq })
+
+	start, end, page := paginate(r, len(matches))
+	projects := []project.Project{}
+	for _, p := range matches[start:end] {
+		projects = append(projects, s.render(p))
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"projects":   projects,
+		"pagination": page,
+	})
+}
+
+func (s *Server) readProject(w http.ResponseWriter, r *http.Request, params []string) {
+	p := s.findProject(r, params[0])
+	if p == nil {
+		notFound(w, "Project %s not found", params[0])
+		return
+	}
+
+	writeJSON(w, http.StatusOK, s.render(p))
+}
+
+func (s *Server) updateProject(w http.ResponseWriter, r *http.Request, params []string) {
+	p := s.findProject(r, params[0])
+	if p == nil {
+		notFound(w, "Project %s not found", params[0])
+		return
+	}
+
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "bad_request", err.Error())
+		return
+	}
+
+	// The id and timestamps are not part of the patch, keep them around
+	updated := p.project
+	if err := patch(&updated, body); err != nil {
+		writeError(w, http.StatusBadRequest, "bad_request", err.Error())
+		return
+	}
+	updated.ID = p.project.ID
+	updated.AccountID = p.project.AccountID
+	updated.CreatedAt = p.project.CreatedAt
+	updated.Link = p.project.Link
+	updated.UpdatedAt = now()
+	p.project = updated
+
+	writeJSON(w, http.StatusOK, s.render(p))
+}
+
+func (s *Server) updateProjectBranch(w http.ResponseWriter, r *http.Request, params []string) {
+	p := s.findProject(r, params[0])
+	if p == nil {
+		notFound(w, "Project %s not found", params[0])
+		return
+	}
+
+	var branch project.Branch
+	if !decode(w, r, &branch) {
+		return
+	}
+	p.project.Link.ProductionBranch = branch.Branch
+	p.project.UpdatedAt = now()
+
+	writeJSON(w, http.StatusOK, s.render(p))
+}
+
+func (s *Server) linkProject(w http.ResponseWriter, r *http.Request, params []string) {
+	p := s.findProject(r, params[0])
+	if p == nil {
+		notFound(w, "Project %s not found", params[0])
+		return
+	}
+
+	var repo project.GitRepository
+	if !decode(w, r, &repo) {
+		return
+	}
+	if repo.Type == "" || repo.Repo == "" {
+		writeError(w, http.StatusBadRequest, "bad_request", "type and repo are required")
+		return
+	}
+	link(p, repo)
+	p.project.UpdatedAt = now()
+
+	writeJSON(w, http.StatusOK, s.render(p))
+}
+
+func (s *Server) unlinkProject(w http.ResponseWriter, r *http.Request, params []string) {
+	p := s.findProject(r, params[0])
+	if p == nil {
+		notFound(w, "Project %s not found", params[0])
+		return
+	}
+
+	p.project.Link = project.Project{}.Link
+	p.project.UpdatedAt = now()
+
+	writeJSON(w, http.StatusOK, s.render(p))
+}
+
+func (s *Server) deleteProject(w http.ResponseWriter, r *http.Request, params []string) {
+	p := s.findProject(r, params[0])
+	if p == nil {
+		notFound(w, "Project %s not found", params[0])
+		return
+	}
+
+	delete(s.projects, p.project.ID)
+	delete(s.envs, p.project.ID)
+	delete(s.projectDomains, p.project.ID)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// findProjectDomain returns the index of the domain in the project's domains or -1.
+func (s *Server) findProjectDomain(projectID, name string) int {
+	for i, d := range s.projectDomains[projectID] {
+		if d.Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+func (s *Server) createProjectDomain(w http.ResponseWriter, r *http.Request, params []string) {
+	p := s.findProject(r, params[0])
+	if p == nil {
+		notFound(w, "Project %s not found", params[0])
+		return
+	}
+
+	var create pdomain.CreateOrUpdateProjectDomain
+	if !decode(w, r, &create) {
+		return
+	}
+	if s.findProjectDomain(p.project.ID, create.Name) >= 0 {
+		writeError(w, http.StatusConflict, "domain_already_in_use", "The domain is already assigned to this project")
+		return
+	}
+
+	d := pdomain.ProjectDomain{
+		Name:      create.Name,
+		ProjectID: p.project.ID,
+		CreatedAt: now(),
+	}
+	d.UpdatedAt = d.CreatedAt
+	applyProjectDomain(&d, create)
+	s.challenge(&d, p.teamID)
+
+	s.projectDomains[p.project.ID] = append(s.projectDomains[p.project.ID], d)
+
+	writeJSON(w, http.StatusOK, d)
+}
+
+func applyProjectDomain(d *pdomain.ProjectDomain, update pdomain.CreateOrUpdateProjectDomain) {
+	d.Redirect = ""
+	if update.Redirect != nil {
+		d.Redirect = *update.Redirect
+	}
+	d.RedirectStatusCode = 0
+	if update.RedirectStatusCode != nil {
+		d.RedirectStatusCode = *update.RedirectStatusCode
+	}
+	d.GitBranch = ""
+	if update.GitBranch != nil {
+		d.GitBranch = *update.GitBranch
+	}
+}
+
+func (s *Server) listProjectDomains(w http.ResponseWriter, r *http.Request, params []string) {
+	p := s.findProject(r, params[0])
+	if p == nil {
+		notFound(w, "Project %s not found", params[0])
+		return
+	}
+
+	all := s.projectDomains[p.project.ID]
+	start, end, page := paginate(r, len(all))
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"domains":    append([]pdomain.ProjectDomain{}, all[start:end]...),
+		"pagination": page,
+	})
+}
+
+func (s *Server) readProjectDomain(w http.ResponseWriter, r *http.Request, params []string) {
+	p := s.findProject(r, params[0])
+	if p == nil {
+		notFound(w, "Project %s not found", params[0])
+		return
+	}
+
+	i := s.findProjectDomain(p.project.ID, params[1])
+	if i < 0 {
+		notFound(w, "Domain %s not found", params[1])
+		return
+	}
+
+	writeJSON(w, http.StatusOK, s.projectDomains[p.project.ID][i])
+}
+
+func (s *Server) updateProjectDomain(w http.ResponseWriter, r *http.Request, params []string) {
+	p := s.findProject(r, params[0])
+	if p == nil {
+		notFound(w, "Project %s not found", params[0])
+		return
+	}
+
+	i := s.findProjectDomain(p.project.ID, params[1])
+	if i < 0 {
+		notFound(w, "Domain %s not found", params[1])
+		return
+	}
+
+	var update pdomain.CreateOrUpdateProjectDomain
+	if !decode(w, r, &update) {
+		return
+	}
+
+	d := &s.projectDomains[p.project.ID][i]
+	applyProjectDomain(d, update)
+	d.UpdatedAt = now()
+
+	writeJSON(w, http.StatusOK, d)
+}
+
+// challenge leaves verified domains of the team verified, every other domain has to prove its ownership with a TXT record.
+func (s *Server) challenge(d *pdomain.ProjectDomain, teamID string) {
+	apex := apexDomain(d.Name)
+	if stored, ok := s.domains[apex]; ok && stored.teamID == teamID && stored.domain.Verified {
+		d.Verified = true
+		d.Verification = nil
+		return
+	}
+
+	d.Verified = false
+	d.Verification = []pdomain.Verification{{
+		Type:   dns.TypeTXT,
+		Domain: "_vercel." + apex,
+		Value:  fmt.Sprintf("vc-domain-verify=%s,%s", d.Name, d.ProjectID),
+		Reason: "pending_domain_verification",
+	}}
+}
+
+func apexDomain(name string) string {
+	labels := strings.Split(name, ".")
+	if len(labels) <= 2 {
+		return name
+	}
+	return strings.Join(labels[len(labels)-2:], ".")
+}
+
+// verifyProjectDomain looks for the TXT record of the challenge in the records of any team.
+func (s *Server) verifyProjectDomain(w http.ResponseWriter, r *http.Request, params []string) {
+	p := s.findProject(r, params[0])
+	if p == nil {
+		notFound(w, "Project %s not found", params[0])
+		return
+	}
+
+	i := s.findProjectDomain(p.project.ID, params[1])
+	if i < 0 {
+		notFound(w, "Domain %s not found", params[1])
+		return
+	}
+
+	d := &s.projectDomains[p.project.ID][i]
+	for _, challenge := range d.Verification {
+		apex := apexDomain(d.Name)
+		for _, record := range s.records[apex] {
+			if record.Type == challenge.Type && record.Name+"."+apex == challenge.Domain && record.Value == challenge.Value {
+				d.Verified = true
+				d.Verification = nil
+				d.UpdatedAt = now()
+			}
+		}
+	}
+	if !d.Verified {
+		writeError(w, http.StatusBadRequest, "missing_txt_record", fmt.Sprintf("Domain %s was not verified, the TXT record was not found", d.Name))
+		return
+	}
+
+	writeJSON(w, http.StatusOK, d)
+}
+
+func (s *Server) deleteProjectDomain(w http.ResponseWriter, r *http.Request, params []string) {
+	p := s.findProject(r, params[0])
+	if p == nil {
+		notFound(w, "Project %s not found", params[0])
+		return
+	}
+
+	i := s.findProjectDomain(p.project.ID, params[1])
+	if i < 0 {
+		notFound(w, "Domain %s not found", params[1])
+		return
+	}
+
+	domains := s.projectDomains[p.project.ID]
+	s.projectDomains[p.project.ID] = append(domains[:i], domains[i+1:]...)
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{})
+}
+
+// The legacy alias endpoints operate on the same domains as the project domain endpoints.
+
+func (s *Server) createAlias(w http.ResponseWriter, r *http.Request, params []string) {
+	p := s.findProject(r, params[0])
+	if p == nil {
+		notFound(w, "Project %s not found", params[0])
+		return
+	}
+
+	var create alias.CreateOrUpdateAlias
+	if !decode(w, r, &create) {
+		return
+	}
+	if s.findProjectDomain(p.project.ID, create.Domain) >= 0 {
+		writeError(w, http.StatusConflict, "domain_already_in_use", "The domain is already assigned to this project")
+		return
+	}
+
+	d := pdomain.ProjectDomain{
+		Name:      create.Domain,
+		Redirect:  create.Redirect,
+		GitBranch: create.Branch,
+		ProjectID: p.project.ID,
+		CreatedAt: now(),
+	}
+	d.UpdatedAt = d.CreatedAt
+	s.challenge(&d, p.teamID)
+	s.projectDomains[p.project.ID] = append(s.projectDomains[p.project.ID], d)
+
+	writeJSON(w, http.StatusOK, s.render(p).Aliases)
+}
+
+func (s *Server) updateAlias(w http.ResponseWriter, r *http.Request, params []string) {
+	p := s.findProject(r, params[0])
+	if p == nil {
+		notFound(w, "Project %s not found", params[0])
+		return
+	}
+
+	var update alias.CreateOrUpdateAlias
+	if !decode(w, r, &update) {
+		return
+	}
+
+	i := s.findProjectDomain(p.project.ID, update.Domain)
+	if i < 0 {
+		notFound(w, "Alias %s not found", update.Domain)
+		return
+	}
+	d := &s.projectDomains[p.project.ID][i]
+	d.Redirect = update.Redirect
+	d.UpdatedAt = now()
+
+	writeJSON(w, http.StatusOK, s.render(p).Aliases)
+}
+
+func (s *Server) deleteAlias(w http.ResponseWriter, r *http.Request, params []string) {
+	p := s.findProject(r, params[0])
+	if p == nil {
+		notFound(w, "Project %s not found", params[0])
+		return
+	}
+
+	name := r.URL.Query().Get("domain")
+	i := s.findProjectDomain(p.project.ID, name)
+	if i < 0 {
+		notFound(w, "Alias %s not found", name)
+		return
+	}
+
+	domains := s.projectDomains[p.project.ID]
+	s.projectDomains[p.project.ID] = append(domains[:i], domains[i+1:]...)
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{})
+}
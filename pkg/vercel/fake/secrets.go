@@ -0,0 +1,113 @@
+package fake
+
+import (
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/chronark/terraform-provider-vercel/pkg/vercel/secret"
+)
+
+func (s *Server) registerSecretRoutes() {
+	s.handle(http.MethodPost, "now/secrets", s.createSecret)
+	s.handle(http.MethodGet, "now/secrets", s.listSecrets)
+	s.handle(http.MethodGet, "now/secrets/*", s.readSecret)
+	s.handle(http.MethodPatch, "now/secrets/*", s.renameSecret)
+	s.handle(http.MethodDelete, "now/secrets/*", s.deleteSecret)
+}
+
+// findSecret looks up a secret by id or name within the team of the request.
+func (s *Server) findSecret(r *http.Request, idOrName string) *storedSecret {
+	teamID := r.URL.Query().Get("teamId")
+	for _, stored := range s.secrets {
+		if stored.secret.TeamID == teamID && (stored.secret.UID == idOrName || stored.secret.Name == idOrName) {
+			return stored
+		}
+	}
+	return nil
+}
+
+func (s *Server) createSecret(w http.ResponseWriter, r *http.Request, params []string) {
+	var create secret.CreateSecret
+	if !decode(w, r, &create) {
+		return
+	}
+	if s.findSecret(r, create.Name) != nil {
+		writeError(w, http.StatusConflict, "conflict", "A secret with this name already exists")
+		return
+	}
+
+	created := time.Now()
+	stored := &storedSecret{
+		secret: secret.Secret{
+			UID:       s.id("sec"),
+			Name:      create.Name,
+			TeamID:    r.URL.Query().Get("teamId"),
+			UserID:    s.user.UID,
+			Created:   created,
+			CreatedAt: created.UnixNano() / int64(time.Millisecond),
+		},
+		value: create.Value,
+	}
+	s.secrets[stored.secret.UID] = stored
+
+	writeJSON(w, http.StatusOK, stored.secret)
+}
+
+// listSecrets returns the secrets of the team sorted by name.
+func (s *Server) listSecrets(w http.ResponseWriter, r *http.Request, params []string) {
+	all := []secret.Secret{}
+	for _, stored := range s.secrets {
+		if stored.secret.TeamID == r.URL.Query().Get("teamId") {
+			all = append(all, stored.secret)
+		}
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i].Name < all[j].Name })
+
+	start, end, page := paginate(r, len(all))
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"secrets":    all[start:end],
+		"pagination": page,
+	})
+}
+
+func (s *Server) readSecret(w http.ResponseWriter, r *http.Request, params []string) {
+	stored := s.findSecret(r, params[0])
+	if stored == nil {
+		notFound(w, "Secret %s not found", params[0])
+		return
+	}
+
+	writeJSON(w, http.StatusOK, stored.secret)
+}
+
+func (s *Server) renameSecret(w http.ResponseWriter, r *http.Request, params []string) {
+	stored := s.findSecret(r, params[0])
+	if stored == nil {
+		notFound(w, "Secret %s not found", params[0])
+		return
+	}
+
+	var rename struct {
+		Name string `json:"name"`
+	}
+	if !decode(w, r, &rename) {
+		return
+	}
+	stored.secret.Name = rename.Name
+
+	writeJSON(w, http.StatusOK, stored.secret)
+}
+
+func (s *Server) deleteSecret(w http.ResponseWriter, r *http.Request, params []string) {
+	stored := s.findSecret(r, params[0])
+	if stored == nil {
+		notFound(w, "Secret %s not found", params[0])
+		return
+	}
+
+	delete(s.secrets, stored.secret.UID)
+
+	writeJSON(w, http.StatusOK, stored.secret)
+}
@@ -0,0 +1,328 @@
+// Package fake provides an in-memory stand-in for the vercel api.
+//
+// The server keeps state between requests, so resources created through it can be read, updated and deleted
+// again. It covers the endpoints used by the provider and is meant for tests that must not touch a real account:
+//
+//	srv := fake.NewServer("token")
+//	defer srv.Close()
+//
+//	config := httpApi.DefaultConfig()
+//	config.BaseURL = srv.URL
+//	client, _ := vercel.New("token", config)
+package fake
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/chronark/terraform-provider-vercel/pkg/vercel/deployment"
+	"github.com/chronark/terraform-provider-vercel/pkg/vercel/dns"
+	"github.com/chronark/terraform-provider-vercel/pkg/vercel/domain"
+	"github.com/chronark/terraform-provider-vercel/pkg/vercel/env"
+	"github.com/chronark/terraform-provider-vercel/pkg/vercel/project"
+	pdomain "github.com/chronark/terraform-provider-vercel/pkg/vercel/project_domain"
+	"github.com/chronark/terraform-provider-vercel/pkg/vercel/secret"
+	"github.com/chronark/terraform-provider-vercel/pkg/vercel/team"
+	"github.com/chronark/terraform-provider-vercel/pkg/vercel/user"
+)
+
+// Server is a stateful fake of the vercel api backed by an `httptest.Server`.
+type Server struct {
+	*httptest.Server
+
+	// Requests must carry this token as bearer token, otherwise they are rejected with 403.
+	Token string
+
+	mu     sync.Mutex
+	nextID int
+	routes []route
+
+	user           user.User
+	teams          map[string]team.Team
+	projects       map[string]*storedProject
+	secrets        map[string]*storedSecret
+	domains        map[string]*storedDomain
+	projectDomains map[string][]pdomain.ProjectDomain
+	envs           map[string][]env.Env
+	records        map[string][]dns.Record
+	files          map[string][]byte
+	deployments    map[string]*storedDeployment
+}
+
+type storedProject struct {
+	teamID  string
+	seq     int
+	project project.Project
+}
+
+type storedSecret struct {
+	secret secret.Secret
+	value  string
+}
+
+type storedDomain struct {
+	teamID string
+	domain domain.Domain
+}
+
+type storedDeployment struct {
+	teamID     string
+	seq        int
+	deployment deployment.Deployment
+}
+
+type route struct {
+	method  string
+	pattern []string
+	handler func(w http.ResponseWriter, r *http.Request, params []string)
+}
+
+// NewServer starts a new fake api that accepts the given token.
+func NewServer(token string) *Server {
+	s := &Server{
+		Token: token,
+		user: user.User{
+			UID:      "usr_fake",
+			Email:    "fake@example.com",
+			Name:     "Fake User",
+			Username: "fake",
+		},
+		teams:          map[string]team.Team{},
+		projects:       map[string]*storedProject{},
+		secrets:        map[string]*storedSecret{},
+		domains:        map[string]*storedDomain{},
+		projectDomains: map[string][]pdomain.ProjectDomain{},
+		envs:           map[string][]env.Env{},
+		records:        map[string][]dns.Record{},
+		files:          map[string][]byte{},
+		deployments:    map[string]*storedDeployment{},
+	}
+
+	s.registerUserRoutes()
+	s.registerProjectRoutes()
+	s.registerEnvRoutes()
+	s.registerSecretRoutes()
+	s.registerDomainRoutes()
+	s.registerDeploymentRoutes()
+
+	s.Server = httptest.NewServer(s)
+
+	return s
+}
+
+// SetUser replaces the authenticated user.
+func (s *Server) SetUser(u user.User) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.user = u
+}
+
+// AddTeam makes a team available for lookups by slug.
+func (s *Server) AddTeam(t team.Team) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.teams[t.Id] = t
+}
+
+func (s *Server) handle(method string, path string, handler func(w http.ResponseWriter, r *http.Request, params []string)) {
+	s.routes = append(s.routes, route{method: method, pattern: strings.Split(path, "/"), handler: handler})
+}
+
+var versionSegment = regexp.MustCompile(`^v\d+$`)
+
+// segments splits the path and drops the api version, the fake serves every version of an endpoint the same way.
+func segments(path string) []string {
+	var segs []string
+	for _, seg := range strings.Split(strings.Trim(path, "/"), "/") {
+		if seg != "" {
+			segs = append(segs, seg)
+		}
+	}
+	if len(segs) > 0 && versionSegment.MatchString(segs[0]) {
+		segs = segs[1:]
+	}
+	return segs
+}
+
+// match compares the path against a pattern where `*` matches any segment and returns the matched segments.
+func match(pattern, segs []string) ([]string, bool) {
+	if len(pattern) != len(segs) {
+		return nil, false
+	}
+
+	var params []string
+	for i, p := range pattern {
+		if p == "*" {
+			params = append(params, segs[i])
+		} else if p != segs[i] {
+			return nil, false
+		}
+	}
+	return params, true
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("Authorization") != fmt.Sprintf("Bearer %s", s.Token) {
+		writeError(w, http.StatusForbidden, "forbidden", "Not authorized")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	segs := segments(r.URL.Path)
+	pathMatched := false
+
+	for _, rt := range s.routes {
+		params, ok := match(rt.pattern, segs)
+		if !ok {
+			continue
+		}
+		pathMatched = true
+		if rt.method == r.Method {
+			rt.handler(w, r, params)
+			return
+		}
+	}
+
+	if pathMatched {
+		writeError(w, http.StatusMethodNotAllowed, "method_not_allowed", fmt.Sprintf("%s is not supported for %s", r.Method, r.URL.Path))
+		return
+	}
+	writeError(w, http.StatusNotFound, "not_found", fmt.Sprintf("The fake api does not implement %s %s", r.Method, r.URL.Path))
+}
+
+func (s *Server) id(prefix string) string {
+	s.nextID++
+	return fmt.Sprintf("%s_%d", prefix, s.nextID)
+}
+
+func now() int64 {
+	return time.Now().UnixNano() / int64(time.Millisecond)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, code string, message string) {
+	body := map[string]interface{}{
+		"error": map[string]string{
+			"code":    code,
+			"message": message,
+		},
+	}
+	writeJSON(w, status, body)
+}
+
+func notFound(w http.ResponseWriter, format string, a ...interface{}) {
+	writeError(w, http.StatusNotFound, "not_found", fmt.Sprintf(format, a...))
+}
+
+// decode reads the request body into v and answers with 400 if that is not possible.
+func decode(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		writeError(w, http.StatusBadRequest, "bad_request", fmt.Sprintf("Invalid request body: %s", err))
+		return false
+	}
+	return true
+}
+
+// patch applies a json merge patch to v: keys in the patch overwrite the current value and `null` resets it.
+func patch(v interface{}, body []byte) error {
+	current, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	var fields map[string]interface{}
+	if err := json.Unmarshal(current, &fields); err != nil {
+		return err
+	}
+
+	var changes map[string]interface{}
+	if err := json.Unmarshal(body, &changes); err != nil {
+		return err
+	}
+
+	for key, value := range changes {
+		if value == nil {
+			delete(fields, key)
+		} else {
+			fields[key] = value
+		}
+	}
+
+	merged, err := json.Marshal(fields)
+	if err != nil {
+		return err
+	}
+
+	// Decode into a zero value so that fields removed by the patch are reset
+	reset(v)
+	return json.Unmarshal(merged, v)
+}
+
+func reset(v interface{}) {
+	rv := reflect.ValueOf(v).Elem()
+	rv.Set(reflect.Zero(rv.Type()))
+}
+
+// paginate selects the page of n items requested through the `limit` and `until` parameters and returns its bounds
+// together with the pagination object of the response. Vercel uses timestamps as cursors, the fake uses positions.
+func paginate(r *http.Request, n int) (int, int, map[string]interface{}) {
+	limit := 20
+	if l, err := strconv.Atoi(r.URL.Query().Get("limit")); err == nil && l > 0 {
+		limit = l
+	}
+	start := 0
+	if u, err := strconv.Atoi(r.URL.Query().Get("until")); err == nil && u > 0 && u <= n {
+		start = u
+	}
+	end := start + limit
+	if end > n {
+		end = n
+	}
+
+	page := map[string]interface{}{
+		"count": end - start,
+		"next":  nil,
+		"prev":  nil,
+	}
+	if end < n {
+		page["next"] = end
+	}
+	if start > 0 {
+		page["prev"] = start
+	}
+	return start, end, page
+}
+
+func (s *Server) registerUserRoutes() {
+	s.handle(http.MethodGet, "www/user", func(w http.ResponseWriter, r *http.Request, params []string) {
+		writeJSON(w, http.StatusOK, map[string]interface{}{"user": s.user})
+	})
+
+	s.handle(http.MethodGet, "teams", func(w http.ResponseWriter, r *http.Request, params []string) {
+		slug := r.URL.Query().Get("slug")
+		for _, t := range s.teams {
+			if t.Slug == slug {
+				writeJSON(w, http.StatusOK, t)
+				return
+			}
+		}
+		notFound(w, "Team %s not found", slug)
+	})
+}
@@ -0,0 +1,43 @@
+package httpApi
+
+import "time"
+
+// Config holds the settings of the api client that users can tune through the provider configuration.
+type Config struct {
+	// How often a failed request is retried before the error is returned.
+	// Only requests that are safe to replay are retried, see `isRetryable`.
+	MaxRetries int
+
+	// The maximum number of requests per second that are sent to vercel. 0 disables client side rate limiting.
+	RateLimitRPS float64
+
+	// The backoff between retries starts at MinRetryWait and doubles with every attempt, but never exceeds MaxRetryWait.
+	MinRetryWait time.Duration
+	MaxRetryWait time.Duration
+
+	// The url of the vercel api, can point to a proxy or a local stand-in.
+	BaseURL string
+
+	// PEM encoded certificates that are trusted in addition to the system's certificate pool.
+	CACertPEM []byte
+
+	// The proxy all requests are sent through. When empty, the standard `HTTPS_PROXY` and `NO_PROXY`
+	// environment variables are used.
+	ProxyURL string
+
+	// The time limit for a single request, including reading the response body. File uploads are only limited
+	// while waiting for the response. 0 means no limit.
+	Timeout time.Duration
+}
+
+// DefaultConfig returns the settings used when nothing else is configured.
+func DefaultConfig() Config {
+	return Config{
+		MaxRetries:   3,
+		RateLimitRPS: 1.25,
+		MinRetryWait: 1 * time.Second,
+		MaxRetryWait: 30 * time.Second,
+		BaseURL:      "https://api.vercel.com",
+		Timeout:      60 * time.Second,
+	}
+}
@@ -0,0 +1,105 @@
+package httpApi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// VercelError is returned for every response with a status code outside of the 2xx range.
+// https://vercel.com/docs/api#api-basics/errors
+type VercelError struct {
+	// The HTTP status code of the response.
+	StatusCode int
+
+	// The machine readable error code, e.g. `not_found` or `forbidden`.
+	Code string
+
+	// A human readable description of the error.
+	Message string
+
+	// The value of the `x-vercel-id` header, useful when reporting issues to vercel.
+	RequestID string
+
+	// The sha1 digests of files a deployment references but that were not uploaded yet.
+	// Only set for `missing_files` errors.
+	Missing []string
+}
+
+func (e *VercelError) Error() string {
+	msg := fmt.Sprintf("vercel responded with status %d", e.StatusCode)
+	if e.Code != "" {
+		msg = fmt.Sprintf("%s (%s)", msg, e.Code)
+	}
+	if e.Message != "" {
+		msg = fmt.Sprintf("%s: %s", msg, e.Message)
+	}
+	if e.RequestID != "" {
+		msg = fmt.Sprintf("%s [request id: %s]", msg, e.RequestID)
+	}
+	return msg
+}
+
+// NewNotFoundError is used by handlers that have to look up an object in a list themselves and
+// want to report a missing object the same way the api does.
+func NewNotFoundError(format string, a ...interface{}) error {
+	return &VercelError{
+		StatusCode: http.StatusNotFound,
+		Code:       "not_found",
+		Message:    fmt.Sprintf(format, a...),
+	}
+}
+
+// errorFromResponse decodes the error body of a failed request.
+func errorFromResponse(res *http.Response) *VercelError {
+	vercelError := &VercelError{
+		StatusCode: res.StatusCode,
+		RequestID:  res.Header.Get("x-vercel-id"),
+	}
+
+	var body struct {
+		Error struct {
+			Code    string   `json:"code"`
+			Message string   `json:"message"`
+			Missing []string `json:"missing"`
+		} `json:"error"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&body); err == nil {
+		vercelError.Code = body.Error.Code
+		vercelError.Message = body.Error.Message
+		vercelError.Missing = body.Error.Missing
+	}
+
+	return vercelError
+}
+
+func hasStatus(err error, statusCode int) bool {
+	var vercelError *VercelError
+	return errors.As(err, &vercelError) && vercelError.StatusCode == statusCode
+}
+
+// IsNotFound reports whether the object does not exist (anymore).
+func IsNotFound(err error) bool {
+	return hasStatus(err, http.StatusNotFound)
+}
+
+// IsForbidden reports whether the token is not allowed to access the object.
+func IsForbidden(err error) bool {
+	return hasStatus(err, http.StatusForbidden)
+}
+
+// IsConflict reports whether the object already exists or is in use.
+func IsConflict(err error) bool {
+	return hasStatus(err, http.StatusConflict)
+}
+
+// IsVerificationFailed reports whether vercel could not verify a domain yet, e.g. because the
+// nameservers or the TXT record are not in place.
+func IsVerificationFailed(err error) bool {
+	var vercelError *VercelError
+	if !errors.As(err, &vercelError) || vercelError.StatusCode != http.StatusBadRequest {
+		return false
+	}
+	return vercelError.Code == "verification_failed" || vercelError.Code == "missing_txt_record"
+}
@@ -0,0 +1,52 @@
+package httpApi
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"golang.org/x/time/rate"
+	"gotest.tools/assert"
+)
+
+func TestRequestReturnsVercelError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("x-vercel-id", "syd1::abc")
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"error":{"code":"not_found","message":"Project not found"}}`))
+	}))
+	defer srv.Close()
+
+	api := &Api{httpClient: srv.Client(), rateLimiter: rate.NewLimiter(rate.Inf, 1), url: srv.URL}
+
+	_, err := api.Request(http.MethodGet, "/v8/projects/prj_123", nil)
+
+	assert.Assert(t, IsNotFound(err))
+	assert.Assert(t, !IsConflict(err))
+
+	var vercelError *VercelError
+	assert.Assert(t, errors.As(err, &vercelError))
+	assert.Equal(t, vercelError.StatusCode, http.StatusNotFound)
+	assert.Equal(t, vercelError.Code, "not_found")
+	assert.Equal(t, vercelError.Message, "Project not found")
+	assert.Equal(t, vercelError.RequestID, "syd1::abc")
+}
+
+func TestIsNotFoundWithWrappedError(t *testing.T) {
+	err := fmt.Errorf("Unable to fetch dns records: %w", NewNotFoundError("Record with id %s was not found", "rec_123"))
+
+	assert.Assert(t, IsNotFound(err))
+	assert.Assert(t, !IsNotFound(fmt.Errorf("something else")))
+}
+
+func TestIsVerificationFailed(t *testing.T) {
+	failed := fmt.Errorf("Unable to verify domain: %w", &VercelError{StatusCode: http.StatusBadRequest, Code: "verification_failed"})
+	forbidden := fmt.Errorf("Unable to verify domain: %w", &VercelError{StatusCode: http.StatusForbidden, Code: "forbidden"})
+
+	assert.Assert(t, IsVerificationFailed(failed))
+	assert.Assert(t, IsVerificationFailed(&VercelError{StatusCode: http.StatusBadRequest, Code: "missing_txt_record"}))
+	assert.Assert(t, !IsVerificationFailed(forbidden))
+	assert.Assert(t, !IsVerificationFailed(fmt.Errorf("something else")))
+}
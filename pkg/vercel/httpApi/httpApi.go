@@ -3,10 +3,16 @@ package httpApi
 import (
 	"bytes"
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"encoding/json"
 	"fmt"
 	"io"
+	"io/ioutil"
+	"log"
 	"net/http"
+	"net/url"
+	"strings"
 	"time"
 
 	"golang.org/x/time/rate"
@@ -14,71 +20,159 @@ import (
 
 type API interface {
 	Request(method string, path string, body interface{}) (*http.Response, error)
+	RequestWithContext(ctx context.Context, method string, path string, body interface{}) (*http.Response, error)
+	RequestRaw(ctx context.Context, method string, path string, body []byte, header http.Header) (*http.Response, error)
 }
 
 type Api struct {
-	httpClient  *http.Client
-	rateLimiter *rate.Limiter
+	httpClient *http.Client
+	// uploadClient sends file uploads, which may take longer than the request timeout
+	uploadClient *http.Client
+	rateLimiter  *rate.Limiter
+	config       Config
 
 	url       string
 	userAgent string
 	token     string
 }
 
-func New(token string) API {
-	return &Api{
-		httpClient:  &http.Client{},
-		rateLimiter: rate.NewLimiter(rate.Every(800*time.Millisecond), 1),
+func New(token string, config Config) (API, error) {
+	limit := rate.Inf
+	if config.RateLimitRPS > 0 {
+		limit = rate.Limit(config.RateLimitRPS)
+	}
+
+	transport, err := newTransport(config)
+	if err != nil {
+		return nil, err
+	}
 
-		url:       "https://api.vercel.com",
+	return &Api{
+		httpClient: &http.Client{
+			Transport: transport,
+			Timeout:   config.Timeout,
+		},
+		uploadClient: &http.Client{
+			Transport: transport,
+		},
+		rateLimiter: rate.NewLimiter(limit, 1),
+		config:      config,
+
+		url:       strings.TrimSuffix(config.BaseURL, "/"),
 		userAgent: "eonx-com/terraform-provider-vercel",
 		token:     token,
-	}
+	}, nil
 }
 
-// https://vercel.com/docs/api#api-basics/errors
-type VercelError struct {
-	Error struct {
-		Code    string `json:"code"`
-		Message string `json:"message"`
-	} `json:"error"`
+func newTransport(config Config) (*http.Transport, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	if config.ProxyURL != "" {
+		proxyURL, err := url.Parse(config.ProxyURL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy url %q: %w", config.ProxyURL, err)
+		}
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	if len(config.CACertPEM) > 0 {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(config.CACertPEM) {
+			return nil, fmt.Errorf("no valid certificates found in the CA bundle")
+		}
+		transport.TLSClientConfig = &tls.Config{RootCAs: pool}
+	}
+
+	// Uploads are not limited as a whole, but the response has to start within the timeout
+	transport.ResponseHeaderTimeout = config.Timeout
+
+	return transport, nil
 }
 
 func (c *Api) setHeaders(req *http.Request) {
 	req.Header.Set("User-Agent", c.userAgent)
-	req.Header.Set("Content-Type", "application/json")
+	if req.Header.Get("Content-Type") == "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
 	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.token))
 }
 
+// Do waits for the rate limiter and sends the request. Both can be cancelled through the request's context.
+// Failed requests are retried with an exponential backoff as long as they are safe to replay.
 func (c *Api) Do(req *http.Request) (*http.Response, error) {
-	ctx := context.Background()
+	return c.do(c.httpClient, req)
+}
 
-	err := c.rateLimiter.Wait(ctx)
+func (c *Api) do(client *http.Client, req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	c.setHeaders(req)
 
-	if err != nil {
-		return nil, err
-	}
+	for attempt := 0; ; attempt++ {
+		if attempt > 0 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req.Body = body
+		}
 
-	c.setHeaders(req)
-	res, err := c.httpClient.Do(req)
+		err := c.rateLimiter.Wait(ctx)
 
-	if err != nil {
-		return nil, fmt.Errorf("unable to perform request: %w", err)
-	}
+		if err != nil {
+			return nil, err
+		}
 
-	if res.StatusCode < 200 || res.StatusCode >= 300 {
-		defer res.Body.Close()
+		res, err := client.Do(req)
+		canRetry := attempt < c.config.MaxRetries && ctx.Err() == nil
 
-		var x map[string]interface{}
-		_ = json.NewDecoder(res.Body).Decode(&x)
+		if err != nil {
+			if canRetry && isRetryable(req.Method, 0) {
+				log.Printf("[DEBUG] %s %s failed, retrying: %s", req.Method, req.URL.Path, err)
+				if err := sleep(ctx, c.backoff(attempt)); err != nil {
+					return nil, err
+				}
+				continue
+			}
+			return nil, fmt.Errorf("unable to perform request: %w", err)
+		}
 
-		return res, fmt.Errorf("error during http request: %+v", x)
-	}
+		if res.StatusCode >= 200 && res.StatusCode < 300 {
+			return res, nil
+		}
 
-	return res, nil
+		if canRetry && isRetryable(req.Method, res.StatusCode) {
+			wait, ok := retryAfter(res, time.Now())
+			if !ok {
+				wait = c.backoff(attempt)
+			}
+
+			// Waiting longer than configured would look like a hanging provider, so we give up instead.
+			if wait <= c.config.MaxRetryWait {
+				_, _ = io.Copy(ioutil.Discard, res.Body)
+				res.Body.Close()
+
+				log.Printf("[DEBUG] %s %s responded with %d, retrying in %s", req.Method, req.URL.Path, res.StatusCode, wait)
+				if err := sleep(ctx, wait); err != nil {
+					return nil, err
+				}
+				continue
+			}
+		}
+
+		defer res.Body.Close()
+
+		return res, errorFromResponse(res)
+	}
 }
 
 func (c *Api) Request(method string, path string, body interface{}) (*http.Response, error) {
+	return c.RequestWithContext(context.Background(), method, path, body)
+}
+
+func (c *Api) RequestWithContext(ctx context.Context, method string, path string, body interface{}) (*http.Response, error) {
 	var payload io.Reader = nil
 
 	if body != nil {
@@ -91,7 +185,7 @@ func (c *Api) Request(method string, path string, body interface{}) (*http.Respo
 		payload = bytes.NewBuffer(b)
 	}
 
-	req, err := http.NewRequest(method, fmt.Sprintf("%s%s", c.url, path), payload)
+	req, err := http.NewRequestWithContext(ctx, method, fmt.Sprintf("%s%s", c.url, path), payload)
 
 	if err != nil {
 		return nil, err
@@ -100,9 +194,31 @@ func (c *Api) Request(method string, path string, body interface{}) (*http.Respo
 	res, err := c.Do(req)
 
 	if err != nil {
-		return res, fmt.Errorf("unable to request resource: [%s] %s with payload {%+v}: %w", method, path, payload, err)
+		// The payload is not part of the error, it may contain secrets like the values of environment variables
+		return res, fmt.Errorf("unable to request resource: [%s] %s: %w", method, path, err)
 	}
 
 	return res, nil
 
 }
+
+// RequestRaw sends the body as it is, e.g. the content of a file. The given headers are added to the request.
+// The request timeout only limits the wait for the response, so large files on slow connections can be uploaded.
+func (c *Api) RequestRaw(ctx context.Context, method string, path string, body []byte, header http.Header) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, fmt.Sprintf("%s%s", c.url, path), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	for key, values := range header {
+		for _, value := range values {
+			req.Header.Add(key, value)
+		}
+	}
+
+	res, err := c.do(c.uploadClient, req)
+	if err != nil {
+		return res, fmt.Errorf("unable to request resource: [%s] %s with %d bytes: %w", method, path, len(body), err)
+	}
+
+	return res, nil
+}
@@ -1,11 +1,16 @@
 package httpApi_test
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"net/http"
+	"net/http/httptest"
 	"sync"
 	"testing"
+	"time"
 
+	"github.com/chronark/terraform-provider-vercel/pkg/vercel/fake"
 	"github.com/chronark/terraform-provider-vercel/pkg/vercel/httpApi"
 	"gotest.tools/assert"
 )
@@ -13,17 +18,26 @@ import (
 func testHttpRequest(t *testing.T, wg *sync.WaitGroup, api httpApi.API) {
 	defer wg.Done()
 
-	res, _ := api.Request(http.MethodGet, "/v8/projects", nil)
+	res, err := api.Request(http.MethodGet, "/v8/projects", nil)
 
+	assert.NilError(t, err)
 	assert.Equal(t, res.StatusCode, http.StatusOK)
 }
 
 func TestHttpApiRateLimiter(t *testing.T) {
-	api := httpApi.New("")
+	srv := fake.NewServer("token")
+	defer srv.Close()
+
+	config := httpApi.DefaultConfig()
+	config.BaseURL = srv.URL
+	config.RateLimitRPS = 100
+	api, err := httpApi.New("token", config)
+	assert.NilError(t, err)
 
 	var wg sync.WaitGroup
+	start := time.Now()
 
-	for i := 0; i < 300; i++ {
+	for i := 0; i < 50; i++ {
 		wg.Add(1)
 		go testHttpRequest(t, &wg, api)
 	}
@@ -31,4 +45,82 @@ func TestHttpApiRateLimiter(t *testing.T) {
 	fmt.Println("Main: Waiting for workers to finish")
 	wg.Wait()
 	fmt.Println("Main: Completed")
+
+	// The first request is sent immediately, the remaining 49 have to wait for the limiter
+	assert.Assert(t, time.Since(start) >= 480*time.Millisecond, "requests were not rate limited: %s", time.Since(start))
+}
+
+func TestHttpApiRequestWithCancelledContext(t *testing.T) {
+	api, err := httpApi.New("", httpApi.DefaultConfig())
+	assert.NilError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err = api.RequestWithContext(ctx, http.MethodGet, "/v8/projects", nil)
+
+	assert.Assert(t, errors.Is(err, context.Canceled))
+}
+
+func TestHttpApiCustomBaseURL(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, r.URL.Path, "/v8/projects")
+		assert.Equal(t, r.Header.Get("Authorization"), "Bearer token")
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	config := httpApi.DefaultConfig()
+	config.BaseURL = srv.URL + "/"
+	api, err := httpApi.New("token", config)
+	assert.NilError(t, err)
+
+	res, err := api.Request(http.MethodGet, "/v8/projects", nil)
+
+	assert.NilError(t, err)
+	assert.Equal(t, res.StatusCode, http.StatusOK)
+}
+
+func TestHttpApiInvalidTransportConfig(t *testing.T) {
+	config := httpApi.DefaultConfig()
+	config.CACertPEM = []byte("not a certificate")
+
+	_, err := httpApi.New("", config)
+	assert.ErrorContains(t, err, "no valid certificates")
+
+	config = httpApi.DefaultConfig()
+	config.ProxyURL = "://proxy"
+
+	_, err = httpApi.New("", config)
+	assert.ErrorContains(t, err, "invalid proxy url")
+}
+
+func TestHttpApiUploadsOutlastTheRequestTimeout(t *testing.T) {
+	// Reads the body slowly, like a slow connection would
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		buf := make([]byte, 1<<20)
+		for {
+			_, err := r.Body.Read(buf)
+			if err != nil {
+				break
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	config := httpApi.DefaultConfig()
+	config.BaseURL = srv.URL
+	config.RateLimitRPS = 0
+	config.MaxRetries = 0
+	config.Timeout = 200 * time.Millisecond
+	api, err := httpApi.New("token", config)
+	assert.NilError(t, err)
+
+	_, err = api.RequestRaw(context.Background(), http.MethodPost, "/v2/files", make([]byte, 64<<20), nil)
+	assert.NilError(t, err)
+
+	_, err = api.Request(http.MethodPost, "/v8/projects", make([]byte, 64<<20))
+	assert.ErrorContains(t, err, "Client.Timeout")
 }
@@ -0,0 +1,86 @@
+package httpApi
+
+import (
+	"context"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// isRetryable reports whether a request may be sent again after it failed with the given status code.
+// A status code of 0 means the request failed before a response was received.
+//
+// 429 responses are always retried because vercel rejected the request without processing it.
+// Everything else is only retried for idempotent methods, where a replay can not create duplicates.
+func isRetryable(method string, statusCode int) bool {
+	if statusCode == http.StatusTooManyRequests {
+		return true
+	}
+
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+	default:
+		return false
+	}
+
+	switch statusCode {
+	case 0, http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+// backoff returns the exponential backoff with jitter for the given attempt, starting at 0.
+func (c *Api) backoff(attempt int) time.Duration {
+	wait := c.config.MinRetryWait << uint(attempt)
+	if wait <= 0 || wait > c.config.MaxRetryWait {
+		wait = c.config.MaxRetryWait
+	}
+
+	// Spread retries of parallel requests between half and the full backoff
+	half := int64(wait / 2)
+	if half <= 0 {
+		return wait
+	}
+	return time.Duration(half + rand.Int63n(half))
+}
+
+// retryAfter reads how long vercel asks us to wait from the `Retry-After` or `X-RateLimit-Reset` headers.
+func retryAfter(res *http.Response, now time.Time) (time.Duration, bool) {
+	if v := res.Header.Get("Retry-After"); v != "" {
+		if seconds, err := strconv.Atoi(v); err == nil {
+			return time.Duration(seconds) * time.Second, true
+		}
+		if date, err := http.ParseTime(v); err == nil {
+			return date.Sub(now), true
+		}
+	}
+
+	// The reset header contains the unix timestamp in seconds at which the current rate limit window ends
+	if v := res.Header.Get("X-RateLimit-Reset"); v != "" && res.StatusCode == http.StatusTooManyRequests {
+		if reset, err := strconv.ParseInt(v, 10, 64); err == nil {
+			return time.Unix(reset, 0).Sub(now), true
+		}
+	}
+
+	return 0, false
+}
+
+// sleep waits for d or until ctx is done.
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
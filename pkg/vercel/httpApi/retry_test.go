@@ -0,0 +1,100 @@
+package httpApi
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"golang.org/x/time/rate"
+	"gotest.tools/assert"
+)
+
+// newRetryTestApi returns an api that talks to a server answering with the given status codes in order.
+// Once all status codes are used up, the server responds with 200.
+func newRetryTestApi(t *testing.T, header http.Header, statusCodes ...int) (*Api, *int32) {
+	var calls int32
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		call := int(atomic.AddInt32(&calls, 1))
+		for k, v := range header {
+			w.Header()[k] = v
+		}
+		if call <= len(statusCodes) {
+			w.WriteHeader(statusCodes[call-1])
+			return
+		}
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	config := Config{MaxRetries: 2, MinRetryWait: time.Millisecond, MaxRetryWait: 50 * time.Millisecond}
+
+	return &Api{httpClient: srv.Client(), rateLimiter: rate.NewLimiter(rate.Inf, 1), config: config, url: srv.URL}, &calls
+}
+
+func TestRetryOnTooManyRequests(t *testing.T) {
+	api, calls := newRetryTestApi(t, http.Header{"Retry-After": []string{"0"}}, http.StatusTooManyRequests)
+
+	res, err := api.Request(http.MethodPost, "/v6/projects", map[string]string{"name": "retry"})
+
+	assert.NilError(t, err)
+	assert.Equal(t, res.StatusCode, http.StatusOK)
+	assert.Equal(t, atomic.LoadInt32(calls), int32(2))
+}
+
+func TestRetryServerErrorsOnlyForIdempotentMethods(t *testing.T) {
+	api, calls := newRetryTestApi(t, nil, http.StatusBadGateway)
+
+	_, err := api.Request(http.MethodPost, "/v6/projects", nil)
+
+	assert.ErrorContains(t, err, "502")
+	assert.Equal(t, atomic.LoadInt32(calls), int32(1))
+
+	api, calls = newRetryTestApi(t, nil, http.StatusBadGateway)
+
+	_, err = api.Request(http.MethodGet, "/v8/projects", nil)
+
+	assert.NilError(t, err)
+	assert.Equal(t, atomic.LoadInt32(calls), int32(2))
+}
+
+func TestRetryGivesUpAfterMaxRetries(t *testing.T) {
+	api, calls := newRetryTestApi(t, nil, http.StatusServiceUnavailable, http.StatusServiceUnavailable, http.StatusServiceUnavailable)
+
+	_, err := api.Request(http.MethodGet, "/v8/projects", nil)
+
+	assert.ErrorContains(t, err, "503")
+	assert.Equal(t, atomic.LoadInt32(calls), int32(3))
+}
+
+func TestRetryGivesUpWhenResetIsTooFarAway(t *testing.T) {
+	reset := time.Now().Add(time.Hour).Unix()
+	api, calls := newRetryTestApi(t, http.Header{"X-Ratelimit-Reset": []string{strconv.FormatInt(reset, 10)}}, http.StatusTooManyRequests)
+	api.config.MaxRetries = 1
+
+	_, err := api.Request(http.MethodGet, "/v8/projects", nil)
+
+	assert.Assert(t, err != nil)
+	assert.Equal(t, atomic.LoadInt32(calls), int32(1))
+}
+
+func TestRetryAfterHeaders(t *testing.T) {
+	now := time.Unix(1600000000, 0)
+
+	wait, ok := retryAfter(&http.Response{Header: http.Header{"Retry-After": []string{"7"}}}, now)
+	assert.Assert(t, ok)
+	assert.Equal(t, wait, 7*time.Second)
+
+	wait, ok = retryAfter(&http.Response{
+		StatusCode: http.StatusTooManyRequests,
+		Header:     http.Header{"X-Ratelimit-Reset": []string{"1600000030"}},
+	}, now)
+	assert.Assert(t, ok)
+	assert.Equal(t, wait, 30*time.Second)
+
+	_, ok = retryAfter(&http.Response{Header: http.Header{}}, now)
+	assert.Assert(t, !ok)
+}
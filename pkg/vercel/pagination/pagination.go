@@ -0,0 +1,114 @@
+// Package pagination walks through the pages of vercel's list endpoints.
+// https://vercel.com/docs/api#api-basics/pagination
+package pagination
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/chronark/terraform-provider-vercel/pkg/vercel/httpApi"
+)
+
+// PageSize is the number of items requested per page unless the query sets its own `limit`.
+const PageSize = 100
+
+// Pagination is the object vercel adds to every list response.
+type Pagination struct {
+	Count int    `json:"count"`
+	Next  *int64 `json:"next"`
+	Prev  *int64 `json:"prev"`
+}
+
+// Pager fetches one page after the other by passing the `next` cursor of a page as `until` parameter
+// of the following request. The module targets Go 1.15, which has no type parameters, so the pager cannot
+// return typed items itself. Every page is decoded into a struct provided by the caller instead:
+//
+//	pager := pagination.New(api, "/v8/projects", query)
+//	for pager.HasNext() {
+//		var page struct {
+//			Projects []project.Project `json:"projects"`
+//		}
+//		if err := pager.Next(ctx, &page); err != nil {
+//			return err
+//		}
+//	}
+type Pager struct {
+	api   httpApi.API
+	path  string
+	query url.Values
+
+	until *int64
+	done  bool
+}
+
+// New returns a pager for the endpoint at path. The query is sent with every request.
+func New(api httpApi.API, path string, query url.Values) *Pager {
+	q := url.Values{}
+	for key, values := range query {
+		q[key] = append([]string{}, values...)
+	}
+	if q.Get("limit") == "" {
+		q.Set("limit", strconv.Itoa(PageSize))
+	}
+
+	return &Pager{api: api, path: path, query: q}
+}
+
+// HasNext reports whether there is another page to fetch.
+func (p *Pager) HasNext() bool {
+	return !p.done
+}
+
+// Next fetches the next page and decodes the response into v.
+func (p *Pager) Next(ctx context.Context, v interface{}) error {
+	if p.done {
+		return fmt.Errorf("there are no more pages for %s", p.path)
+	}
+
+	query := url.Values{}
+	for key, values := range p.query {
+		query[key] = values
+	}
+	if p.until != nil {
+		query.Set("until", strconv.FormatInt(*p.until, 10))
+	}
+
+	res, err := p.api.RequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s?%s", p.path, query.Encode()), nil)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return fmt.Errorf("Unable to read response from %s: %w", p.path, err)
+	}
+
+	err = json.NewDecoder(bytes.NewReader(body)).Decode(v)
+	if err != nil {
+		return fmt.Errorf("Unable to unmarshal response from %s: %w", p.path, err)
+	}
+
+	var page struct {
+		Pagination Pagination `json:"pagination"`
+	}
+	err = json.Unmarshal(body, &page)
+	if err != nil {
+		return fmt.Errorf("Unable to unmarshal pagination from %s: %w", p.path, err)
+	}
+
+	next := page.Pagination.Next
+	// A cursor that does not move would request the same page forever
+	if next == nil || (p.until != nil && *next == *p.until) {
+		p.done = true
+	}
+	p.until = next
+
+	return nil
+}
@@ -0,0 +1,90 @@
+package pagination_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/chronark/terraform-provider-vercel/pkg/vercel/httpApi"
+	"github.com/chronark/terraform-provider-vercel/pkg/vercel/pagination"
+	"github.com/stretchr/testify/require"
+)
+
+// newApi serves the numbers 0 to n-1, newest (highest) first, using the numbers themselves as cursors.
+func newApi(t *testing.T, n int, requests *[]string) httpApi.API {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*requests = append(*requests, r.URL.RawQuery)
+
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		until := n
+		if u := r.URL.Query().Get("until"); u != "" {
+			until, _ = strconv.Atoi(u)
+		}
+
+		items := []int{}
+		for i := until - 1; i >= 0 && len(items) < limit; i-- {
+			items = append(items, i)
+		}
+
+		var next interface{}
+		if len(items) > 0 && items[len(items)-1] > 0 {
+			next = items[len(items)-1]
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"items":      items,
+			"pagination": map[string]interface{}{"count": len(items), "next": next, "prev": nil},
+		})
+	}))
+	t.Cleanup(srv.Close)
+
+	config := httpApi.DefaultConfig()
+	config.BaseURL = srv.URL
+	config.RateLimitRPS = 0
+	api, err := httpApi.New("token", config)
+	require.NoError(t, err)
+	return api
+}
+
+func TestPagerFollowsCursors(t *testing.T) {
+	var requests []string
+	api := newApi(t, 250, &requests)
+
+	pager := pagination.New(api, "/v1/items", nil)
+
+	var items []int
+	for pager.HasNext() {
+		var page struct {
+			Items []int `json:"items"`
+		}
+		require.NoError(t, pager.Next(context.Background(), &page))
+		items = append(items, page.Items...)
+	}
+
+	require.Len(t, items, 250)
+	require.Equal(t, 249, items[0])
+	require.Equal(t, 0, items[249])
+	require.Equal(t, []string{"limit=100", "limit=100&until=150", "limit=100&until=50"}, requests)
+}
+
+func TestPagerKeepsQueryAndLimit(t *testing.T) {
+	var requests []string
+	api := newApi(t, 5, &requests)
+
+	pager := pagination.New(api, "/v1/items", map[string][]string{"limit": {"2"}, "teamId": {"team_123"}})
+
+	pages := 0
+	for pager.HasNext() {
+		var page struct {
+			Items []int `json:"items"`
+		}
+		require.NoError(t, pager.Next(context.Background(), &page))
+		pages++
+	}
+
+	require.Equal(t, 3, pages)
+	require.Equal(t, "limit=2&teamId=team_123&until=1", requests[2])
+	require.Error(t, pager.Next(context.Background(), &struct{}{}))
+}
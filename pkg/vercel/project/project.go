@@ -1,22 +1,26 @@
 package project
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"net/url"
+
 	"github.com/chronark/terraform-provider-vercel/pkg/vercel/httpApi"
+	"github.com/chronark/terraform-provider-vercel/pkg/vercel/pagination"
 )
 
 type ProjectHandler struct {
 	Api httpApi.API
 }
 
-func (p *ProjectHandler) Create(project CreateProject, teamId string) (string, error) {
+func (p *ProjectHandler) Create(ctx context.Context, project CreateProject, teamId string) (string, error) {
 	url := "/v6/projects"
 	if teamId != "" {
 		url = fmt.Sprintf("%s/?teamId=%s", url, teamId)
 	}
 
-	res, err := p.Api.Request("POST", url, project)
+	res, err := p.Api.RequestWithContext(ctx, "POST", url, project)
 	if err != nil {
 		return "", err
 	}
@@ -30,13 +34,13 @@ func (p *ProjectHandler) Create(project CreateProject, teamId string) (string, e
 
 	return createdProject.ID, nil
 }
-func (p *ProjectHandler) Read(id string, teamId string) (project Project, err error) {
+func (p *ProjectHandler) Read(ctx context.Context, id string, teamId string) (project Project, err error) {
 	url := fmt.Sprintf("/v1/projects/%s", id)
 	if teamId != "" {
 		url = fmt.Sprintf("%s/?teamId=%s", url, teamId)
 	}
 
-	res, err := p.Api.Request("GET", url, nil)
+	res, err := p.Api.RequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
 		return Project{}, fmt.Errorf("Unable to fetch project from vercel: %w", err)
 	}
@@ -48,7 +52,34 @@ func (p *ProjectHandler) Read(id string, teamId string) (project Project, err er
 	}
 	return project, nil
 }
-func (p *ProjectHandler) Update(id string, project UpdateProject, teamId string) error {
+
+// List returns all projects whose name contains search, an empty search returns every project.
+// All pages are fetched, so the result can be large for accounts with many projects.
+func (p *ProjectHandler) List(ctx context.Context, search string, teamId string) ([]Project, error) {
+	query := url.Values{}
+	if search != "" {
+		query.Set("search", search)
+	}
+	if teamId != "" {
+		query.Set("teamId", teamId)
+	}
+
+	projects := []Project{}
+	pager := pagination.New(p.Api, "/v8/projects", query)
+	for pager.HasNext() {
+		var page struct {
+			Projects []Project `json:"projects"`
+		}
+		err := pager.Next(ctx, &page)
+		if err != nil {
+			return nil, fmt.Errorf("Unable to fetch projects from vercel: %w", err)
+		}
+		projects = append(projects, page.Projects...)
+	}
+	return projects, nil
+}
+
+func (p *ProjectHandler) Update(ctx context.Context, id string, project UpdateProject, teamId string) error {
 	branch := Branch{
 		Branch: project.Branch,
 	}
@@ -63,6 +94,15 @@ func (p *ProjectHandler) Update(id string, project UpdateProject, teamId string)
 		RootDirectory:            project.RootDirectory,
 		Name:                     project.Name,
 		NodeVersion:              project.NodeVersion,
+
+		CommandForIgnoringBuildStep: project.CommandForIgnoringBuildStep,
+		GitForkProtection:           project.GitForkProtection,
+
+		DirectoryListing:                project.DirectoryListing,
+		AutoExposeSystemEnvs:            project.AutoExposeSystemEnvs,
+		SourceFilesOutsideRootDirectory: project.SourceFilesOutsideRootDirectory,
+		ResourceConfig:                  project.ResourceConfig,
+		SkewProtectionMaxAge:            project.SkewProtectionMaxAge,
 	}
 
 	url := fmt.Sprintf("/v2/projects/%s", id)
@@ -70,7 +110,7 @@ func (p *ProjectHandler) Update(id string, project UpdateProject, teamId string)
 		url = fmt.Sprintf("%s/?teamId=%s", url, teamId)
 	}
 
-	res, err := p.Api.Request("PATCH", url, projectInternal)
+	res, err := p.Api.RequestWithContext(ctx, "PATCH", url, projectInternal)
 	if err != nil {
 		return fmt.Errorf("Unable to update project: %w", err)
 	}
@@ -82,7 +122,7 @@ func (p *ProjectHandler) Update(id string, project UpdateProject, teamId string)
 			branchUrl = fmt.Sprintf("%s/?teamId=%s", branchUrl, teamId)
 		}
 
-		resBranch, errBranch := p.Api.Request("PATCH", branchUrl, branch)
+		resBranch, errBranch := p.Api.RequestWithContext(ctx, "PATCH", branchUrl, branch)
 		if errBranch != nil {
 			return fmt.Errorf("Unable to update project branch: %w", errBranch)
 		}
@@ -91,13 +131,44 @@ func (p *ProjectHandler) Update(id string, project UpdateProject, teamId string)
 
 	return nil
 }
-func (p *ProjectHandler) Delete(id string, teamId string) error {
+
+// Link connects the project to a git repository, an existing connection is replaced.
+func (p *ProjectHandler) Link(ctx context.Context, id string, repo GitRepository, teamId string) error {
+	url := fmt.Sprintf("/v9/projects/%s/link", id)
+	if teamId != "" {
+		url = fmt.Sprintf("%s/?teamId=%s", url, teamId)
+	}
+
+	res, err := p.Api.RequestWithContext(ctx, "POST", url, repo)
+	if err != nil {
+		return fmt.Errorf("Unable to link project to %s: %w", repo.Repo, err)
+	}
+	defer res.Body.Close()
+	return nil
+}
+
+// Unlink disconnects the project from its git repository.
+func (p *ProjectHandler) Unlink(ctx context.Context, id string, teamId string) error {
+	url := fmt.Sprintf("/v9/projects/%s/link", id)
+	if teamId != "" {
+		url = fmt.Sprintf("%s/?teamId=%s", url, teamId)
+	}
+
+	res, err := p.Api.RequestWithContext(ctx, "DELETE", url, nil)
+	if err != nil {
+		return fmt.Errorf("Unable to unlink project: %w", err)
+	}
+	defer res.Body.Close()
+	return nil
+}
+
+func (p *ProjectHandler) Delete(ctx context.Context, id string, teamId string) error {
 	url := fmt.Sprintf("/v1/projects/%s", id)
 	if teamId != "" {
 		url = fmt.Sprintf("%s/?teamId=%s", url, teamId)
 	}
 
-	res, err := p.Api.Request("DELETE", url, nil)
+	res, err := p.Api.RequestWithContext(ctx, "DELETE", url, nil)
 	if err != nil {
 		return fmt.Errorf("Unable to delete project: %w", err)
 	}
@@ -1,6 +1,8 @@
 package project
 
 import (
+	"encoding/json"
+
 	"github.com/chronark/terraform-provider-vercel/pkg/vercel/alias"
 	"github.com/chronark/terraform-provider-vercel/pkg/vercel/env"
 )
@@ -9,6 +11,37 @@ type Branch struct {
 	Branch string `json:"branch"`
 }
 
+// GitRepository identifies the repository a project is connected to.
+type GitRepository struct {
+	// The git provider of the repository, `github`, `gitlab` or `bitbucket`.
+	Type string `json:"type"`
+
+	// The full name of the repository, e.g. `chronark/terraform-provider-vercel`.
+	Repo string `json:"repo"`
+}
+
+// Memory types of serverless functions.
+const (
+	MemoryTypeStandardLegacy = "standard_legacy"
+	MemoryTypeStandard       = "standard"
+	MemoryTypePerformance    = "performance"
+)
+
+// MemoryTypes are all memory types a project can use for its serverless functions.
+var MemoryTypes = []string{MemoryTypeStandardLegacy, MemoryTypeStandard, MemoryTypePerformance}
+
+// ResourceConfig holds the defaults for the serverless functions of a project.
+type ResourceConfig struct {
+	// The memory and cpu of functions, one of MemoryTypes.
+	FunctionDefaultMemoryType string `json:"functionDefaultMemoryType,omitempty"`
+
+	// The maximum duration of functions in seconds.
+	FunctionDefaultTimeout int `json:"functionDefaultTimeout,omitempty"`
+
+	// The regions functions are deployed to.
+	FunctionDefaultRegions []string `json:"functionDefaultRegions,omitempty"`
+}
+
 // Project houses all the information vercel offers about a project via their api
 type Project struct {
 	AccountID string        `json:"accountId"`
@@ -19,28 +52,36 @@ type Project struct {
 		DisabledAt int64  `json:"disabledAt"`
 		CanceledAt int64  `json:"canceledAt"`
 	} `json:"analytics"`
-	AutoExposeSystemEnvs            bool      `json:"autoExposeSystemEnvs"`
-	BuildCommand                    string    `json:"buildCommand"`
-	CreatedAt                       int64     `json:"createdAt"`
-	DevCommand                      string    `json:"devCommand"`
-	DirectoryListing                bool      `json:"directoryListing"`
-	Env                             []env.Env `json:"env"`
-	Framework                       string    `json:"framework"`
-	ID                              string    `json:"id"`
-	InstallCommand                  string    `json:"installCommand"`
-	Name                            string    `json:"name"`
-	NodeVersion                     string    `json:"nodeVersion"`
-	OutputDirectory                 string    `json:"outputDirectory"`
-	PublicSource                    bool      `json:"publicSource"`
-	RootDirectory                   string    `json:"rootDirectory"`
-	ServerlessFunctionRegion        string    `json:"serverlessFunctionRegion"`
-	SourceFilesOutsideRootDirectory bool      `json:"sourceFilesOutsideRootDirectory"`
-	UpdatedAt                       int64     `json:"updatedAt"`
+	AutoExposeSystemEnvs            bool           `json:"autoExposeSystemEnvs"`
+	BuildCommand                    string         `json:"buildCommand"`
+	CommandForIgnoringBuildStep     string         `json:"commandForIgnoringBuildStep"`
+	CreatedAt                       int64          `json:"createdAt"`
+	DevCommand                      string         `json:"devCommand"`
+	DirectoryListing                bool           `json:"directoryListing"`
+	Env                             []env.Env      `json:"env"`
+	Framework                       string         `json:"framework"`
+	GitForkProtection               bool           `json:"gitForkProtection"`
+	ID                              string         `json:"id"`
+	InstallCommand                  string         `json:"installCommand"`
+	Name                            string         `json:"name"`
+	NodeVersion                     string         `json:"nodeVersion"`
+	OutputDirectory                 string         `json:"outputDirectory"`
+	PublicSource                    bool           `json:"publicSource"`
+	ResourceConfig                  ResourceConfig `json:"resourceConfig"`
+	RootDirectory                   string         `json:"rootDirectory"`
+	ServerlessFunctionRegion        string         `json:"serverlessFunctionRegion"`
+	SkewProtectionMaxAge            int            `json:"skewProtectionMaxAge"`
+	SourceFilesOutsideRootDirectory bool           `json:"sourceFilesOutsideRootDirectory"`
+	UpdatedAt                       int64          `json:"updatedAt"`
 	Link                            struct {
 		Type             string        `json:"type"`
 		Repo             string        `json:"repo"`
 		RepoID           int           `json:"repoId"`
 		Org              string        `json:"org"`
+		ProjectName      string        `json:"projectName"`
+		ProjectNamespace string        `json:"projectNamespace"`
+		Owner            string        `json:"owner"`
+		Slug             string        `json:"slug"`
 		GitCredentialID  string        `json:"gitCredentialId"`
 		CreatedAt        int64         `json:"createdAt"`
 		UpdatedAt        int64         `json:"updatedAt"`
@@ -134,79 +175,148 @@ type Project struct {
 
 // CreateProject has all the fields the user can set when creating a new project
 type CreateProject struct {
-	Name          string `json:"name"`
-	GitRepository struct {
-		Type string `json:"type"`
-		Repo string `json:"repo"`
-	} `json:"gitRepository,omitempty"`
+	Name          string         `json:"name"`
+	GitRepository *GitRepository `json:"gitRepository,omitempty"`
 	UpdateProject
 }
 
+// NullableString is a field of an update that is either set to a value or cleared with null.
+// Fields of this type are pointers, a nil pointer leaves the field unchanged.
+type NullableString struct {
+	Value string
+	Null  bool
+}
+
+// StringOrNull returns a field set to value, an empty value is sent as null and clears the field.
+func StringOrNull(value string) *NullableString {
+	return &NullableString{Value: value, Null: value == ""}
+}
+
+// MarshalJSON writes null for a cleared field.
+func (n NullableString) MarshalJSON() ([]byte, error) {
+	if n.Null {
+		return []byte("null"), nil
+	}
+	return json.Marshal(n.Value)
+}
+
+// UnmarshalJSON reads a value or null.
+func (n *NullableString) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*n = NullableString{Null: true}
+		return nil
+	}
+	*n = NullableString{}
+	return json.Unmarshal(data, &n.Value)
+}
+
 // UpdateProject has all the values a user can update without recreating a project
 // https://vercel.com/docs/api#endpoints/projects/update-a-single-project
 type UpdateProject struct {
 	// The framework that is being used for this project. When null is used no framework is selected.
-	Framework string `json:"framework,omitempty"`
+	Framework *NullableString `json:"framework,omitempty"`
 
 	// Specifies whether the source code and logs of the deployments for this project should be public or not.
-	PublicSource bool `json:"publicSource,omitempty"`
+	PublicSource *bool `json:"publicSource,omitempty"`
 
 	// The install command for this project. When null is used this value will be automatically detected.
-	InstallCommand string `json:"installCommand,omitempty"`
+	InstallCommand *NullableString `json:"installCommand,omitempty"`
 
 	// The build command for this project. When null is used this value will be automatically detected.
-	BuildCommand string `json:"buildCommand,omitempty"`
+	BuildCommand *NullableString `json:"buildCommand,omitempty"`
 
 	// The production deployment branch
 	Branch string `json:"branch,omitempty"`
 
 	// The dev command for this project. When null is used this value will be automatically detected.
-	DevCommand string `json:"devCommand,omitempty"`
+	DevCommand *NullableString `json:"devCommand,omitempty"`
 
 	// The output directory of the project. When null is used this value will be automatically detected.
-	OutputDirectory string `json:"outputDirectory,omitempty"`
+	OutputDirectory *NullableString `json:"outputDirectory,omitempty"`
 
 	// The region to deploy Serverless Functions in this project.
 	ServerlessFunctionRegion string `json:"serverlessFunctionRegion,omitempty"`
 
 	// The name of a directory or relative path to the source code of your project. When null is used it will default to the project root.
-	RootDirectory string `json:"rootDirectory,omitempty"`
+	RootDirectory *NullableString `json:"rootDirectory,omitempty"`
 
 	// A new name for this project.
 	Name string `json:"name,omitempty"`
 
 	// The Node.js Version for this project.
 	NodeVersion string `json:"nodeVersion,omitempty"`
+
+	// The command that decides whether a commit is built, exit code 0 skips the build. When null is used no build is skipped.
+	CommandForIgnoringBuildStep *NullableString `json:"commandForIgnoringBuildStep,omitempty"`
+
+	// Whether deployments of pull requests from forks require an authorization by a team member.
+	GitForkProtection *bool `json:"gitForkProtection,omitempty"`
+
+	// Whether a listing of the files is shown for paths that point to a directory without an index file.
+	DirectoryListing *bool `json:"directoryListing,omitempty"`
+
+	// Whether the system environment variables are exposed to builds and functions automatically.
+	AutoExposeSystemEnvs *bool `json:"autoExposeSystemEnvs,omitempty"`
+
+	// Whether files outside of the root directory are available to builds.
+	SourceFilesOutsideRootDirectory *bool `json:"sourceFilesOutsideRootDirectory,omitempty"`
+
+	// The defaults for serverless functions, the whole config is replaced.
+	ResourceConfig *ResourceConfig `json:"resourceConfig,omitempty"`
+
+	// How long previous deployments keep serving clients of older versions in seconds, 0 disables skew protection.
+	SkewProtectionMaxAge *int `json:"skewProtectionMaxAge,omitempty"`
 }
 
 type UpdateProjectInternal struct {
 	// The framework that is being used for this project. When null is used no framework is selected.
-	Framework string `json:"framework,omitempty"`
+	Framework *NullableString `json:"framework,omitempty"`
 
 	// Specifies whether the source code and logs of the deployments for this project should be public or not.
-	PublicSource bool `json:"publicSource,omitempty"`
+	PublicSource *bool `json:"publicSource,omitempty"`
 
 	// The install command for this project. When null is used this value will be automatically detected.
-	InstallCommand string `json:"installCommand,omitempty"`
+	InstallCommand *NullableString `json:"installCommand,omitempty"`
 
 	// The build command for this project. When null is used this value will be automatically detected.
-	BuildCommand string `json:"buildCommand,omitempty"`
+	BuildCommand *NullableString `json:"buildCommand,omitempty"`
 
 	// The dev command for this project. When null is used this value will be automatically detected.
-	DevCommand string `json:"devCommand,omitempty"`
+	DevCommand *NullableString `json:"devCommand,omitempty"`
 
 	// The output directory of the project. When null is used this value will be automatically detected.
-	OutputDirectory string `json:"outputDirectory,omitempty"`
+	OutputDirectory *NullableString `json:"outputDirectory,omitempty"`
 
 	// The region to deploy Serverless Functions in this project.
 	ServerlessFunctionRegion string `json:"serverlessFunctionRegion,omitempty"`
 
 	// The name of a directory or relative path to the source code of your project. When null is used it will default to the project root.
-	RootDirectory string `json:"rootDirectory,omitempty"`
+	RootDirectory *NullableString `json:"rootDirectory,omitempty"`
 
 	// A new name for this project.
 	Name string `json:"name,omitempty"`
 
 	// The Node.js Version for this project.
 	NodeVersion string `json:"nodeVersion,omitempty"`
+
+	// The command that decides whether a commit is built, exit code 0 skips the build. When null is used no build is skipped.
+	CommandForIgnoringBuildStep *NullableString `json:"commandForIgnoringBuildStep,omitempty"`
+
+	// Whether deployments of pull requests from forks require an authorization by a team member.
+	GitForkProtection *bool `json:"gitForkProtection,omitempty"`
+
+	// Whether a listing of the files is shown for paths that point to a directory without an index file.
+	DirectoryListing *bool `json:"directoryListing,omitempty"`
+
+	// Whether the system environment variables are exposed to builds and functions automatically.
+	AutoExposeSystemEnvs *bool `json:"autoExposeSystemEnvs,omitempty"`
+
+	// Whether files outside of the root directory are available to builds.
+	SourceFilesOutsideRootDirectory *bool `json:"sourceFilesOutsideRootDirectory,omitempty"`
+
+	// The defaults for serverless functions, the whole config is replaced.
+	ResourceConfig *ResourceConfig `json:"resourceConfig,omitempty"`
+
+	// How long previous deployments keep serving clients of older versions in seconds, 0 disables skew protection.
+	SkewProtectionMaxAge *int `json:"skewProtectionMaxAge,omitempty"`
 }
@@ -0,0 +1,23 @@
+package project
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdateClearsEmptyFields(t *testing.T) {
+	update := UpdateProjectInternal{
+		BuildCommand: StringOrNull(""),
+		DevCommand:   StringOrNull("next dev"),
+	}
+
+	body, err := json.Marshal(update)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"buildCommand": null, "devCommand": "next dev"}`, string(body))
+
+	var decoded UpdateProjectInternal
+	require.NoError(t, json.Unmarshal(body, &decoded))
+	require.Equal(t, "next dev", decoded.DevCommand.Value)
+}
@@ -1,6 +1,7 @@
 package pdomain
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -27,16 +28,37 @@ type ProjectDomain struct {
 	ProjectID          string `json:"projectId"`
 	CreatedAt          int64  `json:"createdAt"`
 	UpdatedAt          int64  `json:"updatedAt"`
+
+	// Whether the ownership of the domain is verified, requests to unverified domains are not served.
+	Verified bool `json:"verified"`
+
+	// The challenges of which one has to be satisfied to verify the domain, empty once it is verified.
+	Verification []Verification `json:"verification"`
+}
+
+// Verification is a DNS record that proves the ownership of a domain.
+type Verification struct {
+	// The type of the record, usually `TXT`.
+	Type string `json:"type"`
+
+	// The fully qualified name of the record, e.g. `_vercel.chronark.com`.
+	Domain string `json:"domain"`
+
+	// The value of the record.
+	Value string `json:"value"`
+
+	// Why the domain has to be verified, e.g. because it is used by another account.
+	Reason string `json:"reason"`
 }
 
-func (h *Handler) Read(projectID, teamID, domainName string) (ProjectDomain, error) {
+func (h *Handler) Read(ctx context.Context, projectID, teamID, domainName string) (ProjectDomain, error) {
 	url := fmt.Sprintf("/v8/projects/%s/domains/%s", projectID, domainName)
 
 	if teamID != "" {
 		url = fmt.Sprintf("%s?teamId=%s", url, teamID)
 	}
 
-	res, err := h.Api.Request("GET", url, nil)
+	res, err := h.Api.RequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
 		return ProjectDomain{}, fmt.Errorf("unable to fetch project domain from vercel: %w", err)
 	}
@@ -52,14 +74,14 @@ func (h *Handler) Read(projectID, teamID, domainName string) (ProjectDomain, err
 	return domain, nil
 }
 
-func (h *Handler) Create(projectID, teamID string, dto CreateOrUpdateProjectDomain) (*ProjectDomain, error) {
+func (h *Handler) Create(ctx context.Context, projectID, teamID string, dto CreateOrUpdateProjectDomain) (*ProjectDomain, error) {
 	url := fmt.Sprintf("/v8/projects/%s/domains", projectID)
 
 	if teamID != "" {
 		url = fmt.Sprintf("%s?teamId=%s", url, teamID)
 	}
 
-	res, err := h.Api.Request(http.MethodPost, url, dto)
+	res, err := h.Api.RequestWithContext(ctx, http.MethodPost, url, dto)
 
 	if err != nil {
 		return nil, fmt.Errorf("unable to create project domain from vercel: %w", err)
@@ -77,14 +99,14 @@ func (h *Handler) Create(projectID, teamID string, dto CreateOrUpdateProjectDoma
 	return &domain, nil
 }
 
-func (h *Handler) Update(projectID, teamID, domainID string, dto CreateOrUpdateProjectDomain) (*ProjectDomain, error) {
-	url := fmt.Sprintf("/v1/projects/%s/domains/%s", projectID, domainID)
+func (h *Handler) Update(ctx context.Context, projectID, teamID, domainID string, dto CreateOrUpdateProjectDomain) (*ProjectDomain, error) {
+	url := fmt.Sprintf("/v9/projects/%s/domains/%s", projectID, domainID)
 
 	if teamID != "" {
 		url = fmt.Sprintf("%s?teamId=%s", url, teamID)
 	}
 
-	res, err := h.Api.Request(http.MethodPatch, url, dto)
+	res, err := h.Api.RequestWithContext(ctx, http.MethodPatch, url, dto)
 
 	if err != nil {
 		return nil, fmt.Errorf("unable to update project domain from vercel: %w", err)
@@ -102,14 +124,14 @@ func (h *Handler) Update(projectID, teamID, domainID string, dto CreateOrUpdateP
 	return &domain, nil
 }
 
-func (h *Handler) Delete(projectID, teamID, domainName string) error {
+func (h *Handler) Delete(ctx context.Context, projectID, teamID, domainName string) error {
 	url := fmt.Sprintf("/v8/projects/%s/domains/%s", projectID, domainName)
 
 	if teamID != "" {
 		url = fmt.Sprintf("%s?teamId=%s", url, teamID)
 	}
 
-	res, err := h.Api.Request(http.MethodDelete, url, nil)
+	res, err := h.Api.RequestWithContext(ctx, http.MethodDelete, url, nil)
 
 	if err != nil {
 		return fmt.Errorf("unable to delete project domain from vercel: %w", err)
@@ -119,3 +141,29 @@ func (h *Handler) Delete(projectID, teamID, domainName string) error {
 
 	return nil
 }
+
+// Verify checks the verification challenge of an unverified domain. It fails as long as the challenge is not satisfied.
+func (h *Handler) Verify(ctx context.Context, projectID, teamID, domainName string) (*ProjectDomain, error) {
+	url := fmt.Sprintf("/v9/projects/%s/domains/%s/verify", projectID, domainName)
+
+	if teamID != "" {
+		url = fmt.Sprintf("%s?teamId=%s", url, teamID)
+	}
+
+	res, err := h.Api.RequestWithContext(ctx, http.MethodPost, url, nil)
+
+	if err != nil {
+		return nil, fmt.Errorf("unable to verify project domain: %w", err)
+	}
+
+	defer res.Body.Close()
+
+	var domain ProjectDomain
+
+	err = json.NewDecoder(res.Body).Decode(&domain)
+	if err != nil {
+		return nil, fmt.Errorf("unable to unmarshal project domain response: %w", err)
+	}
+
+	return &domain, nil
+}
@@ -1,6 +1,7 @@
 package secret
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"github.com/chronark/terraform-provider-vercel/pkg/vercel/httpApi"
@@ -25,8 +26,13 @@ type Handler struct {
 	Api httpApi.API
 }
 
-func (h *Handler) Create(secret CreateSecret) (string, error) {
-	res, err := h.Api.Request("POST", "/v2/now/secrets", secret)
+func (h *Handler) Create(ctx context.Context, secret CreateSecret, teamId string) (string, error) {
+	url := "/v2/now/secrets"
+	if teamId != "" {
+		url = fmt.Sprintf("%s/?teamId=%s", url, teamId)
+	}
+
+	res, err := h.Api.RequestWithContext(ctx, "POST", url, secret)
 	if err != nil {
 		return "", err
 	}
@@ -42,13 +48,13 @@ func (h *Handler) Create(secret CreateSecret) (string, error) {
 }
 
 // Read returns environment variables associated with a project
-func (h *Handler) Read(secretID, teamId string) (secret Secret, err error) {
+func (h *Handler) Read(ctx context.Context, secretID, teamId string) (secret Secret, err error) {
 	url := fmt.Sprintf("/v3/now/secrets/%s", secretID)
 	if teamId != "" {
 		url = fmt.Sprintf("%s/?teamId=%s", url, teamId)
 	}
 
-	res, err := h.Api.Request("GET", url, nil)
+	res, err := h.Api.RequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
 		return Secret{}, fmt.Errorf("Unable to fetch secret from vercel: %w", err)
 	}
@@ -60,7 +66,7 @@ func (h *Handler) Read(secretID, teamId string) (secret Secret, err error) {
 	}
 	return secret, nil
 }
-func (h *Handler) Update(oldName, newName, teamId string) error {
+func (h *Handler) Update(ctx context.Context, oldName, newName, teamId string) error {
 	url := fmt.Sprintf("/v2/now/secrets/%s", oldName)
 	if teamId != "" {
 		url = fmt.Sprintf("%s/?teamId=%s", url, teamId)
@@ -71,19 +77,19 @@ func (h *Handler) Update(oldName, newName, teamId string) error {
 		Name: newName,
 	}
 
-	res, err := h.Api.Request("PATCH", url, payload)
+	res, err := h.Api.RequestWithContext(ctx, "PATCH", url, payload)
 	if err != nil {
 		return fmt.Errorf("Unable to update secret: %w", err)
 	}
 	defer res.Body.Close()
 	return nil
 }
-func (h *Handler) Delete(secretName, teamId string) error {
+func (h *Handler) Delete(ctx context.Context, secretName, teamId string) error {
 	url := fmt.Sprintf("/v2/now/secrets/%s", secretName)
 	if teamId != "" {
 		url = fmt.Sprintf("%s/?teamId=%s", url, teamId)
 	}
-	res, err := h.Api.Request("DELETE", url, nil)
+	res, err := h.Api.RequestWithContext(ctx, "DELETE", url, nil)
 	if err != nil {
 		return fmt.Errorf("Unable to delete secret: %w", err)
 	}
@@ -1,6 +1,7 @@
 package team
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"time"
@@ -21,8 +22,8 @@ type Handler struct {
 	Api httpApi.API
 }
 
-func (h *Handler) Read(slug string) (user Team, err error) {
-	res, err := h.Api.Request("GET", fmt.Sprintf("/v1/teams/?slug=%s", slug), nil)
+func (h *Handler) Read(ctx context.Context, slug string) (user Team, err error) {
+	res, err := h.Api.RequestWithContext(ctx, "GET", fmt.Sprintf("/v1/teams/?slug=%s", slug), nil)
 	if err != nil {
 		return Team{}, fmt.Errorf("Unable to fetch team from vercel: %w", err)
 	}
@@ -1,6 +1,7 @@
 package user
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"github.com/chronark/terraform-provider-vercel/pkg/vercel/httpApi"
@@ -32,8 +33,8 @@ type UserHandler struct {
 	Api httpApi.API
 }
 
-func (p *UserHandler) Read() (user User, err error) {
-	res, err := p.Api.Request("GET", "/www/user", nil)
+func (p *UserHandler) Read(ctx context.Context) (user User, err error) {
+	res, err := p.Api.RequestWithContext(ctx, "GET", "/www/user", nil)
 	if err != nil {
 		return User{}, fmt.Errorf("Unable to fetch user from vercel: %w", err)
 	}